@@ -14,7 +14,11 @@ import (
 	"github.com/barzurustami/bozor/internal/db"
 	"github.com/barzurustami/bozor/internal/graphql"
 	"github.com/barzurustami/bozor/internal/logger"
+	"github.com/barzurustami/bozor/internal/metrics"
 	"github.com/barzurustami/bozor/internal/middleware"
+	"github.com/barzurustami/bozor/internal/migrate"
+	"github.com/barzurustami/bozor/internal/tracing"
+	"github.com/barzurustami/bozor/migrations"
 	"go.uber.org/zap"
 )
 
@@ -33,24 +37,89 @@ func main() {
 	}
 	defer log.Sync()
 
+	if cfg.Trace.Enabled {
+		exporter, err := tracing.NewOTLPExporter(ctx)
+		if err != nil {
+			log.Fatal("trace exporter setup failed", zap.Error(err))
+		}
+		provider := tracing.NewProvider(tracing.Config{
+			ServiceName: cfg.Trace.ServiceName,
+			Environment: cfg.App.Env,
+			SampleRatio: cfg.Trace.SampleRatio,
+		}, exporter)
+		tracing.Install(provider)
+		defer func() {
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := provider.Shutdown(flushCtx); err != nil {
+				log.Error("trace provider shutdown error", zap.Error(err))
+			}
+		}()
+	}
+
 	pool, err := db.Connect(ctx, cfg.DB.DSN())
 	if err != nil {
 		log.Fatal("db connect failed", zap.Error(err))
 	}
 	defer pool.Close()
 
+	if cfg.DB.MigrateOnStartup {
+		migrator, err := migrate.New(pool, migrations.FS)
+		if err != nil {
+			log.Fatal("load migrations failed", zap.Error(err))
+		}
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatal("migrate failed", zap.Error(err))
+		}
+		log.Info("migrations applied", zap.Int("count", len(applied)))
+	}
+
+	metrics.RegisterPool(pool)
+
 	repos := app.NewRepositories(pool)
 	services := app.NewServices(cfg, repos, log)
 	resolver := app.NewResolver(services, repos)
 
-	gqlServer := graphql.NewServer(resolver, cfg.Upload.MaxSizeBytes)
+	dispatcher := app.NewDispatcher(cfg, repos, services)
+	go dispatcher.Run(logger.WithContext(ctx, log))
+	go app.NewWebhookDeliverer(cfg, repos).Run(logger.WithContext(ctx, log))
+
+	checker, err := app.NewHealthChecker(cfg, pool, services)
+	if err != nil {
+		log.Fatal("health checker setup failed", zap.Error(err))
+	}
+
+	persistedQueries := graphql.NewPersistedQueryCache(repos.PersistedQueries, graphql.PersistedQueryOptions{
+		CacheSize:     cfg.GQL.APQCacheSize,
+		MaxQueryBytes: cfg.GQL.APQMaxQueryBytes,
+		MaxStored:     cfg.GQL.APQMaxStored,
+		TTL:           cfg.GQL.APQTTL,
+	})
+	go persistedQueries.Run(logger.WithContext(ctx, log))
+
+	gqlServer := graphql.NewServer(resolver, graphql.Options{
+		MaxUploadBytes:       cfg.Upload.MaxSizeBytes,
+		ExposeInternalErrors: cfg.App.Env == "local",
+		Introspection:        cfg.GQL.Introspection,
+		ComplexityLimit:      cfg.GQL.ComplexityLimit,
+		DepthLimit:           cfg.GQL.DepthLimit,
+		PersistedQueries:     persistedQueries,
+	})
 
 	mux := http.NewServeMux()
+	mux.Handle("/healthz", checker.Liveness())
+	mux.Handle("/readyz", checker.Readiness())
+	mux.Handle("/metrics", metrics.Handler())
 	mux.Handle("/graphql", graphql.MaxBytes(cfg.Upload.MaxSizeBytes, gqlServer))
-	mux.Handle("/", playground.Handler("Bozor GraphQL", "/graphql"))
+	if cfg.GQL.Playground {
+		mux.Handle("/", playground.Handler("Bozor GraphQL", "/graphql"))
+	}
 	mux.Handle("/uploads/", http.StripPrefix("/uploads/", http.FileServer(http.Dir(cfg.Upload.Dir))))
 
-	h := middleware.RequestID(mux)
+	h := middleware.Metrics(mux)
+	h = middleware.Tracing(h)
+	h = middleware.RequestID(h)
 	h = middleware.Logging(log)(h)
 	h = middleware.Auth(services.JWT)(h)
 
@@ -69,6 +138,13 @@ func main() {
 	}()
 
 	<-ctx.Done()
+
+	// Fail readiness first so the orchestrator stops routing new traffic
+	// while in-flight requests finish.
+	checker.SetReady(false)
+	log.Info("shutting down", zap.Duration("readiness_delay", cfg.Health.ShutdownDelay))
+	time.Sleep(cfg.Health.ShutdownDelay)
+
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/config"
+	"github.com/barzurustami/bozor/internal/db"
+	"github.com/barzurustami/bozor/internal/migrate"
+	"github.com/barzurustami/bozor/migrations"
+)
+
+const usage = `usage: migrate <command>
+
+commands:
+  up         apply all pending migrations
+  down [n]   revert the last n applied migrations (default 1)
+  status     list migrations and when they were applied
+  redo       revert and reapply the last applied migration`
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	if len(os.Args) < 2 {
+		return errors.New(usage)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	pool, err := db.Connect(ctx, cfg.DB.DSN())
+	if err != nil {
+		return fmt.Errorf("db connect failed: %w", err)
+	}
+	defer pool.Close()
+
+	migrator, err := migrate.New(pool, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	switch os.Args[1] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("applied %03d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			steps, err = strconv.Atoi(os.Args[2])
+			if err != nil || steps <= 0 {
+				return fmt.Errorf("invalid step count %q", os.Args[2])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			fmt.Printf("reverted %03d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			fmt.Println("no applied migrations")
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%03d_%-40s %s\n", s.Migration.Version, s.Migration.Name, appliedAt)
+		}
+	case "redo":
+		redone, err := migrator.Redo(ctx)
+		if err != nil {
+			return err
+		}
+		if redone == nil {
+			fmt.Println("no applied migrations")
+		} else {
+			fmt.Printf("redid %03d_%s\n", redone.Version, redone.Name)
+		}
+	default:
+		return errors.New(usage)
+	}
+
+	return nil
+}
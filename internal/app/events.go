@@ -0,0 +1,49 @@
+package app
+
+import (
+	"github.com/barzurustami/bozor/internal/config"
+	"github.com/barzurustami/bozor/internal/events"
+	"github.com/barzurustami/bozor/internal/service"
+	"github.com/barzurustami/bozor/internal/webhook"
+)
+
+// NewDispatcher wires the in-process event handlers to the outbox.
+func NewDispatcher(cfg *config.Config, repos *Repositories, services *Services) *events.Dispatcher {
+	bus := events.NewBus()
+	bus.Subscribe(events.NewLogHandler())
+	bus.Subscribe(
+		service.NewNotificationHandler(
+			services.Notification,
+			services.Chat,
+			services.Push,
+			services.SMS,
+			repos.Users,
+			repos.Participants,
+			repos.Messages,
+			repos.ChatSettings,
+			repos.Profiles,
+		),
+		events.TypeChatCreated,
+		events.TypeChatMessageSent,
+	)
+	bus.Subscribe(webhook.NewHandler(repos.Webhooks, repos.WebhookDeliveries), webhook.EventTypes...)
+
+	return events.NewDispatcher(repos.Outbox, bus, events.DispatcherConfig{
+		PollInterval: cfg.Events.PollInterval,
+		BatchSize:    cfg.Events.BatchSize,
+		Lease:        cfg.Events.Lease,
+		MaxAttempts:  cfg.Events.MaxAttempts,
+	})
+}
+
+// NewWebhookDeliverer sends the deliveries queued by the webhook handler.
+func NewWebhookDeliverer(cfg *config.Config, repos *Repositories) *webhook.Deliverer {
+	return webhook.NewDeliverer(repos.Webhooks, repos.WebhookDeliveries, webhook.DelivererConfig{
+		PollInterval: cfg.Hooks.PollInterval,
+		BatchSize:    cfg.Hooks.BatchSize,
+		Lease:        cfg.Hooks.Lease,
+		Timeout:      cfg.Hooks.Timeout,
+		MaxAttempts:  cfg.Hooks.MaxAttempts,
+		RetryBase:    cfg.Hooks.RetryBase,
+	})
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/barzurustami/bozor/internal/config"
+	"github.com/barzurustami/bozor/internal/health"
+	"github.com/barzurustami/bozor/internal/migrate"
+	"github.com/barzurustami/bozor/internal/sms"
+	"github.com/barzurustami/bozor/migrations"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewHealthChecker registers the readiness checks for the API's dependencies.
+func NewHealthChecker(cfg *config.Config, pool *pgxpool.Pool, services *Services) (*health.Checker, error) {
+	migrator, err := migrate.New(pool, migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	checker := health.NewChecker(cfg.Health.CheckTimeout)
+	checker.Register("database", pool.Ping)
+	checker.Register("migrations", func(ctx context.Context) error {
+		pending, err := migrator.Pending(ctx)
+		// A database ahead of this binary is expected mid-rollout.
+		if err != nil && !errors.Is(err, migrate.ErrUnknownVersion) {
+			return err
+		}
+		if pending > 0 {
+			return fmt.Errorf("%d pending migrations", pending)
+		}
+		return nil
+	})
+	checker.Register("storage", services.Storage.CheckWritable)
+	if pinger, ok := services.SMS.(sms.Pinger); ok {
+		checker.Register("sms", pinger.Ping)
+	}
+
+	return checker, nil
+}
@@ -7,21 +7,53 @@ import (
 )
 
 type Repositories struct {
-	Users    repository.UserRepository
-	Profiles repository.ProfileRepository
-	Requests repository.RequestRepository
-	Photos   repository.PhotoRepository
-	Chats    repository.ChatRepository
-	Messages repository.MessageRepository
+	Tx                   repository.Transactor
+	Users                repository.UserRepository
+	Profiles             repository.ProfileRepository
+	Requests             repository.RequestRepository
+	Photos               repository.PhotoRepository
+	Chats                repository.ChatRepository
+	Participants         repository.ParticipantRepository
+	ChatSettings         repository.ChatSettingsRepository
+	Messages             repository.MessageRepository
+	Reactions            repository.ReactionRepository
+	Attachments          repository.AttachmentRepository
+	ReadCursors          repository.ReadCursorRepository
+	DeliveryCursors      repository.DeliveryCursorRepository
+	Blocks               repository.BlockRepository
+	Reports              repository.ReportRepository
+	Outbox               repository.OutboxRepository
+	PersistedQueries     repository.PersistedQueryRepository
+	Notifications        repository.NotificationRepository
+	NotificationSettings repository.NotificationSettingsRepository
+	Devices              repository.DeviceRepository
+	Webhooks             repository.WebhookRepository
+	WebhookDeliveries    repository.WebhookDeliveryRepository
 }
 
 func NewRepositories(pool *pgxpool.Pool) *Repositories {
 	return &Repositories{
-		Users:    postgres.NewUserRepository(pool),
-		Profiles: postgres.NewProfileRepository(pool),
-		Requests: postgres.NewRequestRepository(pool),
-		Photos:   postgres.NewPhotoRepository(pool),
-		Chats:    postgres.NewChatRepository(pool),
-		Messages: postgres.NewMessageRepository(pool),
+		Tx:                   postgres.NewTxManager(pool),
+		Users:                postgres.NewUserRepository(pool),
+		Profiles:             postgres.NewProfileRepository(pool),
+		Requests:             postgres.NewRequestRepository(pool),
+		Photos:               postgres.NewPhotoRepository(pool),
+		Chats:                postgres.NewChatRepository(pool),
+		Participants:         postgres.NewParticipantRepository(pool),
+		ChatSettings:         postgres.NewChatSettingsRepository(pool),
+		Messages:             postgres.NewMessageRepository(pool),
+		Reactions:            postgres.NewReactionRepository(pool),
+		Attachments:          postgres.NewAttachmentRepository(pool),
+		ReadCursors:          postgres.NewReadCursorRepository(pool),
+		DeliveryCursors:      postgres.NewDeliveryCursorRepository(pool),
+		Blocks:               postgres.NewBlockRepository(pool),
+		Reports:              postgres.NewReportRepository(pool),
+		Outbox:               postgres.NewOutboxRepository(pool),
+		PersistedQueries:     postgres.NewPersistedQueryRepository(pool),
+		Notifications:        postgres.NewNotificationRepository(pool),
+		NotificationSettings: postgres.NewNotificationSettingsRepository(pool),
+		Devices:              postgres.NewDeviceRepository(pool),
+		Webhooks:             postgres.NewWebhookRepository(pool),
+		WebhookDeliveries:    postgres.NewWebhookDeliveryRepository(pool),
 	}
 }
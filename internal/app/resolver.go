@@ -1,15 +1,27 @@
 package app
 
-import "github.com/barzurustami/bozor/internal/graphql/resolvers"
+import (
+	"github.com/barzurustami/bozor/internal/dataloader"
+	"github.com/barzurustami/bozor/internal/graphql/resolvers"
+)
 
 func NewResolver(services *Services, repos *Repositories) *resolvers.Resolver {
 	return &resolvers.Resolver{
-		AuthService:    services.Auth,
-		ProfileService: services.Profile,
-		RequestService: services.Request,
-		PhotoService:   services.Photo,
-		ChatService:    services.Chat,
-		UserRepo:       repos.Users,
-		ProfileRepo:    repos.Profiles,
+		AuthService:         services.Auth,
+		ProfileService:      services.Profile,
+		RequestService:      services.Request,
+		PhotoService:        services.Photo,
+		ChatService:         services.Chat,
+		ModerationService:   services.Moderation,
+		NotificationService: services.Notification,
+		PushService:         services.Push,
+		WebhookService:      services.Webhook,
+		UserRepo:            repos.Users,
+		Loaders: dataloader.Sources{
+			Users:    repos.Users,
+			Profiles: repos.Profiles,
+			Requests: repos.Requests,
+			Photos:   repos.Photos,
+		},
 	}
 }
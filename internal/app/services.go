@@ -3,36 +3,102 @@ package app
 import (
 	"github.com/barzurustami/bozor/internal/auth"
 	"github.com/barzurustami/bozor/internal/config"
+	"github.com/barzurustami/bozor/internal/events"
+	"github.com/barzurustami/bozor/internal/push"
 	"github.com/barzurustami/bozor/internal/service"
 	"github.com/barzurustami/bozor/internal/sms"
+	"github.com/barzurustami/bozor/internal/spam"
 	"github.com/barzurustami/bozor/internal/storage"
 	"go.uber.org/zap"
 )
 
 type Services struct {
-	Auth    *service.AuthService
-	Profile *service.ProfileService
-	Request *service.RequestService
-	Photo   *service.PhotoService
-	Chat    *service.ChatService
-	JWT     *auth.JWTService
+	Auth         *service.AuthService
+	Profile      *service.ProfileService
+	Request      *service.RequestService
+	Photo        *service.PhotoService
+	Chat         *service.ChatService
+	Moderation   *service.ModerationService
+	Notification *service.NotificationService
+	Push         *service.PushService
+	Webhook      *service.WebhookService
+	JWT          *auth.JWTService
+	SMS          sms.Sender
+	Storage      *storage.LocalStorage
 }
 
 func NewServices(cfg *config.Config, repos *Repositories, log *zap.Logger) *Services {
 	jwtSvc := auth.NewJWTService(cfg.JWT.AccessSecret, cfg.JWT.RefreshSecret, cfg.JWT.AccessTTL, cfg.JWT.RefreshTTL)
-	smsSender := sms.NewMockSender()
+	var smsSender sms.Sender = sms.NewInstrumentedSender(sms.NewMockSender(), "mock")
 	if cfg.SMS.Provider != "mock" {
 		log.Warn("sms provider not configured, falling back to mock", zap.String("provider", cfg.SMS.Provider))
 	}
 
+	var pushSender push.Sender
+	switch cfg.Push.Provider {
+	case "fcm":
+		pushSender = push.NewInstrumentedSender(push.NewFCMSender(cfg.Push.FCMEndpoint, cfg.Push.FCMServerKey, cfg.Push.Timeout), "fcm")
+	default:
+		if cfg.Push.Provider != "mock" {
+			log.Warn("push provider not configured, falling back to mock", zap.String("provider", cfg.Push.Provider))
+		}
+		pushSender = push.NewInstrumentedSender(push.NewMockSender(), "mock")
+	}
+
 	storageSvc := storage.NewLocalStorage(cfg.Upload.Dir, cfg.Upload.MaxSizeBytes)
+	publisher := events.NewPublisher(repos.Outbox)
+
+	chatSvc := service.NewChatService(
+		repos.Tx,
+		repos.Chats,
+		repos.Participants,
+		repos.ChatSettings,
+		repos.Messages,
+		repos.Reactions,
+		repos.Attachments,
+		repos.ReadCursors,
+		repos.DeliveryCursors,
+		repos.Requests,
+		repos.Users,
+		repos.Blocks,
+		storageSvc,
+		publisher,
+		cfg.Chat.EditWindow,
+		spam.Policy{
+			ChatCreateLimit:  cfg.Spam.ChatCreateLimit,
+			ChatCreateWindow: cfg.Spam.ChatCreateWindow,
+			MessageLimit:     cfg.Spam.MessageLimit,
+			MessageWindow:    cfg.Spam.MessageWindow,
+			DuplicateChats:   cfg.Spam.DuplicateChats,
+			DuplicateWindow:  cfg.Spam.DuplicateWindow,
+			LinkAction:       spam.ParseAction(cfg.Spam.LinkPolicy),
+			PhoneAction:      spam.ParseAction(cfg.Spam.PhonePolicy),
+			DuplicateAction:  spam.ParseAction(cfg.Spam.DuplicatePolicy),
+		},
+	)
 
 	return &Services{
 		JWT:     jwtSvc,
+		SMS:     smsSender,
+		Storage: storageSvc,
 		Auth:    service.NewAuthService(repos.Users, smsSender, jwtSvc),
 		Profile: service.NewProfileService(repos.Profiles),
-		Request: service.NewRequestService(repos.Requests),
-		Photo:   service.NewPhotoService(storageSvc, repos.Photos, repos.Requests),
-		Chat:    service.NewChatService(repos.Chats, repos.Messages, repos.Requests, storageSvc),
+		Request: service.NewRequestService(repos.Tx, repos.Requests, publisher),
+		Photo:   service.NewPhotoService(repos.Tx, storageSvc, repos.Photos, repos.Requests, chatSvc, publisher),
+		Chat:    chatSvc,
+		Moderation: service.NewModerationService(
+			repos.Blocks,
+			repos.Reports,
+			repos.Users,
+			repos.Chats,
+			repos.Participants,
+			repos.Messages,
+			repos.Attachments,
+			chatSvc,
+			cfg.App.AdminUserIDs,
+		),
+		Notification: service.NewNotificationService(repos.Tx, repos.Notifications, repos.NotificationSettings),
+		Push:         service.NewPushService(repos.Devices, pushSender),
+		Webhook:      service.NewWebhookService(repos.Webhooks, repos.WebhookDeliveries, cfg.App.AdminUserIDs),
 	}
 }
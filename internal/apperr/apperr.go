@@ -0,0 +1,66 @@
+// Package apperr defines application errors that carry a client-facing code.
+// Errors without a code are internal and their details are not shown to
+// clients outside local development.
+package apperr
+
+import "errors"
+
+type Code string
+
+const (
+	CodeUnauthenticated Code = "UNAUTHENTICATED"
+	CodeForbidden       Code = "FORBIDDEN"
+	CodeNotFound        Code = "NOT_FOUND"
+	CodeValidation      Code = "VALIDATION"
+	CodeRateLimited     Code = "RATE_LIMITED"
+	CodeInternal        Code = "INTERNAL"
+)
+
+// ErrUnauthenticated is returned when an operation needs a signed-in user.
+var ErrUnauthenticated = New(CodeUnauthenticated, "unauthorized")
+
+// Error is an error safe to show to clients. Sentinel errors are declared as
+// *Error values so errors.Is keeps working on them.
+type Error struct {
+	Code    Code
+	Message string
+	// Fields maps input field paths to what is wrong with them. It is only
+	// set on VALIDATION errors.
+	Fields map[string]string
+	Err    error
+}
+
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Validation is shorthand for a VALIDATION error.
+func Validation(message string) *Error {
+	return New(CodeValidation, message)
+}
+
+// Wrap attaches a code and client message to err, which stays reachable
+// through errors.Is and errors.As but is not shown to clients.
+func Wrap(code Code, message string, err error) *Error {
+	return &Error{Code: code, Message: message, Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// CodeOf returns the code of the first *Error in err's chain, or CodeInternal.
+func CodeOf(err error) Code {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return CodeInternal
+}
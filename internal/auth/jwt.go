@@ -1,15 +1,15 @@
 package auth
 
 import (
-	"errors"
 	"time"
 
+	"github.com/barzurustami/bozor/internal/apperr"
 	"github.com/barzurustami/bozor/internal/domain"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
-var ErrInvalidToken = errors.New("invalid token")
+var ErrInvalidToken = apperr.New(apperr.CodeUnauthenticated, "invalid token")
 
 type JWTService struct {
 	accessSecret  []byte
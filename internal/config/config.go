@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 )
 
@@ -15,13 +17,23 @@ type Config struct {
 	DB     DBConfig
 	JWT    JWTConfig
 	SMS    SMSConfig
+	Push   PushConfig
 	Upload UploadConfig
+	Chat   ChatConfig
+	Spam   SpamConfig
+	Events EventsConfig
+	Hooks  WebhookConfig
+	Health HealthConfig
+	Trace  TraceConfig
+	GQL    GraphQLConfig
 }
 
 type AppConfig struct {
 	Env      string
 	Port     string
 	LogLevel string
+	// AdminUserIDs may manage webhooks.
+	AdminUserIDs []uuid.UUID
 }
 
 type DBConfig struct {
@@ -31,6 +43,9 @@ type DBConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+	// MigrateOnStartup applies pending migrations before the API starts
+	// serving.
+	MigrateOnStartup bool
 }
 
 type JWTConfig struct {
@@ -46,17 +61,109 @@ type SMSConfig struct {
 	Sender   string
 }
 
+// PushConfig selects the push provider: "mock" logs pushes, "fcm" sends them
+// to an FCM-style HTTP gateway.
+type PushConfig struct {
+	Provider     string
+	FCMEndpoint  string
+	FCMServerKey string
+	Timeout      time.Duration
+}
+
 type UploadConfig struct {
 	Dir          string
 	MaxSizeBytes int64
 }
 
+type ChatConfig struct {
+	EditWindow time.Duration
+}
+
+// SpamConfig holds chat rate limits and the action (allow, flag or hold) for
+// each content heuristic.
+type SpamConfig struct {
+	ChatCreateLimit  int
+	ChatCreateWindow time.Duration
+	MessageLimit     int
+	MessageWindow    time.Duration
+	DuplicateChats   int
+	DuplicateWindow  time.Duration
+	LinkPolicy       string
+	PhonePolicy      string
+	DuplicatePolicy  string
+}
+
+type EventsConfig struct {
+	PollInterval time.Duration
+	BatchSize    int32
+	Lease        time.Duration
+	MaxAttempts  int
+}
+
+// WebhookConfig paces the webhook deliverer. Failed deliveries are retried
+// with exponential backoff from RetryBase until MaxAttempts.
+type WebhookConfig struct {
+	PollInterval time.Duration
+	BatchSize    int32
+	Lease        time.Duration
+	Timeout      time.Duration
+	MaxAttempts  int
+	RetryBase    time.Duration
+}
+
+// HealthConfig bounds each readiness check and sets how long /readyz reports
+// not-ready before the server stops accepting connections on shutdown.
+type HealthConfig struct {
+	CheckTimeout  time.Duration
+	ShutdownDelay time.Duration
+}
+
+// GraphQLConfig limits what a single operation may cost and controls the
+// developer tooling exposed by the server.
+type GraphQLConfig struct {
+	ComplexityLimit int
+	DepthLimit      int
+	Introspection   bool
+	Playground      bool
+	APQCacheSize    int
+	// Persisted documents larger than APQMaxQueryBytes still run but are not
+	// stored. Stored documents are dropped after APQTTL without use, and only
+	// the APQMaxStored most recently used are kept.
+	APQMaxQueryBytes int
+	APQMaxStored     int
+	APQTTL           time.Duration
+}
+
+// TraceConfig enables OpenTelemetry tracing. The OTLP endpoint is read by the
+// exporter from the standard OTEL_EXPORTER_OTLP_* variables.
+type TraceConfig struct {
+	Enabled     bool
+	ServiceName string
+	SampleRatio float64
+}
+
 func Load() (*Config, error) {
 	_ = godotenv.Load()
 
+	env := getEnv("APP_ENV", "local")
+
+	// Contact details and links are common in local test data, so only
+	// deployed environments act on them by default.
+	contentPolicy, duplicatePolicy := "allow", "flag"
+	if env != "local" {
+		contentPolicy, duplicatePolicy = "flag", "hold"
+	}
+
+	// Deployed instances keep serving briefly after readiness flips so the
+	// load balancer can stop routing to them first.
+	shutdownDelay := time.Duration(0)
+	if env != "local" {
+		shutdownDelay = 5 * time.Second
+	}
+
 	cfg := &Config{
 		App: AppConfig{
-			Env:      getEnv("APP_ENV", "local"),
+			Env:      env,
 			Port:     getEnv("APP_PORT", "8080"),
 			LogLevel: getEnv("APP_LOG_LEVEL", "info"),
 		},
@@ -67,6 +174,8 @@ func Load() (*Config, error) {
 			Password: getEnv("DB_PASSWORD", "postgres"),
 			Name:     getEnv("DB_NAME", "bozor"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			MigrateOnStartup: getEnvBool("MIGRATE_ON_STARTUP", false),
 		},
 		JWT: JWTConfig{
 			AccessSecret:  getEnv("JWT_ACCESS_SECRET", "dev_access_secret"),
@@ -79,16 +188,75 @@ func Load() (*Config, error) {
 			APIKey:   getEnv("SMS_API_KEY", ""),
 			Sender:   getEnv("SMS_SENDER", "BOZOR"),
 		},
+		Push: PushConfig{
+			Provider:     getEnv("PUSH_PROVIDER", "mock"),
+			FCMEndpoint:  getEnv("PUSH_FCM_ENDPOINT", "https://fcm.googleapis.com/fcm/send"),
+			FCMServerKey: getEnv("PUSH_FCM_SERVER_KEY", ""),
+			Timeout:      getEnvDuration("PUSH_TIMEOUT", 10*time.Second),
+		},
 		Upload: UploadConfig{
 			Dir:          getEnv("UPLOAD_DIR", "./uploads"),
 			MaxSizeBytes: getEnvInt64("UPLOAD_MAX_MB", 25) * 1024 * 1024,
 		},
+		Chat: ChatConfig{
+			EditWindow: getEnvDuration("CHAT_EDIT_WINDOW", 15*time.Minute),
+		},
+		Spam: SpamConfig{
+			ChatCreateLimit:  int(getEnvInt64("SPAM_CHAT_CREATE_LIMIT", 20)),
+			ChatCreateWindow: getEnvDuration("SPAM_CHAT_CREATE_WINDOW", time.Hour),
+			MessageLimit:     int(getEnvInt64("SPAM_MESSAGE_LIMIT", 30)),
+			MessageWindow:    getEnvDuration("SPAM_MESSAGE_WINDOW", time.Minute),
+			DuplicateChats:   int(getEnvInt64("SPAM_DUPLICATE_CHATS", 3)),
+			DuplicateWindow:  getEnvDuration("SPAM_DUPLICATE_WINDOW", time.Hour),
+			LinkPolicy:       getEnv("SPAM_LINK_POLICY", contentPolicy),
+			PhonePolicy:      getEnv("SPAM_PHONE_POLICY", contentPolicy),
+			DuplicatePolicy:  getEnv("SPAM_DUPLICATE_POLICY", duplicatePolicy),
+		},
+		Events: EventsConfig{
+			PollInterval: getEnvDuration("EVENTS_POLL_INTERVAL", 2*time.Second),
+			BatchSize:    int32(getEnvInt64("EVENTS_BATCH_SIZE", 50)),
+			Lease:        getEnvDuration("EVENTS_LEASE", time.Minute),
+			MaxAttempts:  int(getEnvInt64("EVENTS_MAX_ATTEMPTS", 10)),
+		},
+		Hooks: WebhookConfig{
+			PollInterval: getEnvDuration("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+			BatchSize:    int32(getEnvInt64("WEBHOOK_BATCH_SIZE", 20)),
+			Lease:        getEnvDuration("WEBHOOK_LEASE", time.Minute),
+			Timeout:      getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:  int(getEnvInt64("WEBHOOK_MAX_ATTEMPTS", 8)),
+			RetryBase:    getEnvDuration("WEBHOOK_RETRY_BASE", 30*time.Second),
+		},
+		Health: HealthConfig{
+			CheckTimeout:  getEnvDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+			ShutdownDelay: getEnvDuration("HEALTH_SHUTDOWN_DELAY", shutdownDelay),
+		},
+		GQL: GraphQLConfig{
+			ComplexityLimit:  int(getEnvInt64("GRAPHQL_COMPLEXITY_LIMIT", 500)),
+			DepthLimit:       int(getEnvInt64("GRAPHQL_DEPTH_LIMIT", 10)),
+			Introspection:    getEnvBool("GRAPHQL_INTROSPECTION", env != "production"),
+			Playground:       getEnvBool("GRAPHQL_PLAYGROUND", env != "production"),
+			APQCacheSize:     int(getEnvInt64("GRAPHQL_APQ_CACHE_SIZE", 1000)),
+			APQMaxQueryBytes: int(getEnvInt64("GRAPHQL_APQ_MAX_QUERY_BYTES", 32<<10)),
+			APQMaxStored:     int(getEnvInt64("GRAPHQL_APQ_MAX_STORED", 10000)),
+			APQTTL:           getEnvDuration("GRAPHQL_APQ_TTL", 30*24*time.Hour),
+		},
+		Trace: TraceConfig{
+			Enabled:     getEnvBool("TRACING_ENABLED", false),
+			ServiceName: getEnv("TRACING_SERVICE_NAME", "bozor-api"),
+			SampleRatio: getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+		},
 	}
 
 	if cfg.JWT.AccessSecret == cfg.JWT.RefreshSecret {
 		return nil, fmt.Errorf("jwt access and refresh secrets must differ")
 	}
 
+	adminIDs, err := getEnvUUIDs("ADMIN_USER_IDS")
+	if err != nil {
+		return nil, err
+	}
+	cfg.App.AdminUserIDs = adminIDs
+
 	return cfg, nil
 }
 
@@ -134,3 +302,45 @@ func getEnvInt64(key string, fallback int64) int64 {
 	}
 	return parsed
 }
+
+func getEnvBool(key string, fallback bool) bool {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseBool(val)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
+
+// getEnvUUIDs parses a comma-separated list of UUIDs. A malformed entry is an
+// error rather than silently dropped, since the list grants access.
+func getEnvUUIDs(key string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, part := range strings.Split(os.Getenv(key), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := uuid.Parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid user id %q", key, part)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
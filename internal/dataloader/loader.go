@@ -0,0 +1,100 @@
+// Package dataloader batches lookups made while resolving one GraphQL
+// response into a single query per entity type.
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FetchFunc loads the values for keys. Keys without a value are left out of
+// the map and resolve to the zero value.
+type FetchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Loader collects keys requested within wait of each other, or until maxBatch
+// keys are pending, and fetches them together. Results are cached for the
+// loader's lifetime, so a Loader must not outlive the response it serves.
+type Loader[K comparable, V any] struct {
+	ctx      context.Context
+	fetch    FetchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	calls   map[K]*call[V]
+	pending []K
+	timer   *time.Timer
+}
+
+// NewLoader runs fetches with ctx, detached from the cancellation of the
+// individual field that happened to trigger a batch.
+func NewLoader[K comparable, V any](ctx context.Context, fetch FetchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		ctx:      context.WithoutCancel(ctx),
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		calls:    make(map[K]*call[V]),
+	}
+}
+
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	c, ok := l.calls[key]
+	if !ok {
+		c = &call[V]{done: make(chan struct{})}
+		l.calls[key] = c
+		l.pending = append(l.pending, key)
+
+		switch {
+		case len(l.pending) >= l.maxBatch:
+			if l.timer != nil {
+				l.timer.Stop()
+				l.timer = nil
+			}
+			go l.dispatch()
+		case len(l.pending) == 1:
+			l.timer = time.AfterFunc(l.wait, l.dispatch)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.value, c.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *Loader[K, V]) dispatch() {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	l.timer = nil
+	calls := make([]*call[V], len(keys))
+	for i, key := range keys {
+		calls[i] = l.calls[key]
+	}
+	l.mu.Unlock()
+
+	// The timer and a full batch can both trigger a dispatch; whichever runs
+	// second finds nothing pending.
+	if len(keys) == 0 {
+		return
+	}
+
+	values, err := l.fetch(l.ctx, keys)
+	for i, key := range keys {
+		calls[i].value, calls[i].err = values[key], err
+		close(calls[i].done)
+	}
+}
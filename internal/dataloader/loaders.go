@@ -0,0 +1,91 @@
+package dataloader
+
+import (
+	"context"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	batchWait = 2 * time.Millisecond
+	maxBatch  = 100
+)
+
+// Sources are the repositories the loaders read from.
+type Sources struct {
+	Users    repository.UserRepository
+	Profiles repository.ProfileRepository
+	Requests repository.RequestRepository
+	Photos   repository.PhotoRepository
+}
+
+type Loaders struct {
+	Users    *Loader[uuid.UUID, *domain.User]
+	Profiles *Loader[uuid.UUID, *domain.Profile]
+	Requests *Loader[uuid.UUID, *domain.JobRequest]
+	// Photos is keyed by request id.
+	Photos *Loader[uuid.UUID, []domain.Photo]
+}
+
+func NewLoaders(ctx context.Context, src Sources) *Loaders {
+	return &Loaders{
+		Users: NewLoader(ctx, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.User, error) {
+			users, err := src.Users.ListByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uuid.UUID]*domain.User, len(users))
+			for i := range users {
+				result[users[i].ID] = &users[i]
+			}
+			return result, nil
+		}, batchWait, maxBatch),
+		Profiles: NewLoader(ctx, func(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*domain.Profile, error) {
+			profiles, err := src.Profiles.ListByUserIDs(ctx, userIDs)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uuid.UUID]*domain.Profile, len(profiles))
+			for i := range profiles {
+				result[profiles[i].UserID] = &profiles[i]
+			}
+			return result, nil
+		}, batchWait, maxBatch),
+		Requests: NewLoader(ctx, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.JobRequest, error) {
+			requests, err := src.Requests.ListByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uuid.UUID]*domain.JobRequest, len(requests))
+			for i := range requests {
+				result[requests[i].ID] = &requests[i]
+			}
+			return result, nil
+		}, batchWait, maxBatch),
+		Photos: NewLoader(ctx, func(ctx context.Context, requestIDs []uuid.UUID) (map[uuid.UUID][]domain.Photo, error) {
+			photos, err := src.Photos.ListByRequestIDs(ctx, requestIDs)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uuid.UUID][]domain.Photo, len(requestIDs))
+			for _, photo := range photos {
+				result[photo.RequestID] = append(result[photo.RequestID], photo)
+			}
+			return result, nil
+		}, batchWait, maxBatch),
+	}
+}
+
+type loadersKey struct{}
+
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loaders)
+}
+
+func FromContext(ctx context.Context) (*Loaders, bool) {
+	loaders, ok := ctx.Value(loadersKey{}).(*Loaders)
+	return loaders, ok
+}
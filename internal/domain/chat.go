@@ -13,4 +13,8 @@ type Chat struct {
 	InitiatorID   uuid.UUID
 	CreatedAt     time.Time
 	LastMessageAt *time.Time
+
+	// Settings holds the viewing user's settings when the chat was loaded
+	// for a specific user.
+	Settings ChatUserSettings
 }
@@ -6,12 +6,62 @@ import (
 	"github.com/google/uuid"
 )
 
+type MessageKind string
+
+const (
+	MessageKindUser   MessageKind = "user"
+	MessageKindSystem MessageKind = "system"
+)
+
+type SystemEvent string
+
+const (
+	SystemEventParticipantAdded   SystemEvent = "participant_added"
+	SystemEventParticipantRemoved SystemEvent = "participant_removed"
+	SystemEventParticipantLeft    SystemEvent = "participant_left"
+	SystemEventRequestPhotosAdded SystemEvent = "request_photos_added"
+)
+
+// ModerationStatus is the spam-check outcome of a message. Held messages are
+// visible to their sender only.
+type ModerationStatus string
+
+const (
+	ModerationStatusClean   ModerationStatus = "clean"
+	ModerationStatusFlagged ModerationStatus = "flagged"
+	ModerationStatusHeld    ModerationStatus = "held"
+)
+
+// SystemPayload describes the event behind a system message.
+type SystemPayload struct {
+	Event SystemEvent    `json:"event"`
+	Data  map[string]any `json:"data,omitempty"`
+}
+
 type ChatMessage struct {
 	ID        uuid.UUID
 	ChatID    uuid.UUID
 	SenderID  uuid.UUID
 	Text      string
 	PhotoPath string
-	CreatedAt time.Time
-	ReadAt    *time.Time
+	ReplyToID *uuid.UUID
+	Kind      MessageKind
+	// System is set for system messages only.
+	System           *SystemPayload
+	ModerationStatus ModerationStatus
+	// ModerationReasons lists the heuristics that flagged or held the
+	// message. It is only populated when the message is created.
+	ModerationReasons []string
+	CreatedAt         time.Time
+	// ReadAt and DeliveredAt are derived from the other participants' cursors.
+	ReadAt      *time.Time
+	DeliveredAt *time.Time
+	EditedAt    *time.Time
+	// DeletedAt marks a tombstone: the message was deleted for everyone and
+	// its content has been cleared.
+	DeletedAt *time.Time
+}
+
+func (m ChatMessage) Cursor() MessageCursor {
+	return MessageCursor{CreatedAt: m.CreatedAt, ID: m.ID}
 }
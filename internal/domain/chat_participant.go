@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ParticipantRole string
+
+const (
+	ParticipantRoleOwner  ParticipantRole = "owner"
+	ParticipantRoleMember ParticipantRole = "member"
+)
+
+type ChatParticipant struct {
+	ChatID   uuid.UUID
+	UserID   uuid.UUID
+	Role     ParticipantRole
+	JoinedAt time.Time
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChatUserSettings is one participant's personal view of a chat.
+type ChatUserSettings struct {
+	ChatID     uuid.UUID
+	UserID     uuid.UUID
+	ArchivedAt *time.Time
+	MutedUntil *time.Time
+	PinnedAt   *time.Time
+	UpdatedAt  time.Time
+}
+
+// Muted reports whether notifications for the chat are silenced at the given time.
+func (s ChatUserSettings) Muted(at time.Time) bool {
+	return s.MutedUntil != nil && s.MutedUntil.After(at)
+}
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryCursor marks the newest message that reached a participant's device;
+// every earlier message in the chat counts as delivered too.
+type DeliveryCursor struct {
+	ChatID                 uuid.UUID
+	UserID                 uuid.UUID
+	LastDeliveredMessageID uuid.UUID
+	DeliveredAt            time.Time
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DevicePlatform string
+
+const (
+	DevicePlatformIOS     DevicePlatform = "ios"
+	DevicePlatformAndroid DevicePlatform = "android"
+	DevicePlatformWeb     DevicePlatform = "web"
+)
+
+// Device is an app installation that can receive push notifications.
+type Device struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Platform  DevicePlatform
+	Token     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
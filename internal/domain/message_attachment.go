@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MessageAttachment struct {
+	ID           uuid.UUID
+	MessageID    uuid.UUID
+	Path         string
+	MimeType     string
+	SizeBytes    int64
+	OriginalName string
+	DurationMs   *int
+	CreatedAt    time.Time
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MessageCursor is a position in a chat's message timeline.
+type MessageCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+type MessageSearchHit struct {
+	Message ChatMessage
+	// Snippet is the matching part of the message text with the matched
+	// terms wrapped in <mark> tags.
+	Snippet string
+}
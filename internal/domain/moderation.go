@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserBlock struct {
+	BlockerID uuid.UUID
+	BlockedID uuid.UUID
+	CreatedAt time.Time
+}
+
+type ReportStatus string
+
+const (
+	ReportStatusPending   ReportStatus = "pending"
+	ReportStatusReviewed  ReportStatus = "reviewed"
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+type ModerationReport struct {
+	ID             uuid.UUID
+	ReporterID     uuid.UUID
+	ChatID         *uuid.UUID
+	MessageID      *uuid.UUID
+	ReportedUserID *uuid.UUID
+	Reason         string
+	Snapshot       ReportSnapshot
+	Status         ReportStatus
+	CreatedAt      time.Time
+}
+
+// ReportSnapshot is the copy of the reported content stored with a report.
+type ReportSnapshot struct {
+	ChatID    uuid.UUID         `json:"chat_id"`
+	RequestID uuid.UUID         `json:"request_id"`
+	Messages  []ReportedMessage `json:"messages"`
+}
+
+type ReportedMessage struct {
+	ID          uuid.UUID  `json:"id"`
+	SenderID    uuid.UUID  `json:"sender_id"`
+	Text        string     `json:"text"`
+	Attachments []string   `json:"attachments,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	EditedAt    *time.Time `json:"edited_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+}
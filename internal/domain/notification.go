@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+// Offers are chats opened on a customer's request. Nothing produces
+// OfferAccepted or ReviewReceived until accepting offers and reviews exist.
+const (
+	NotificationTypeNewMessage     NotificationType = "new_message"
+	NotificationTypeNewOffer       NotificationType = "new_offer"
+	NotificationTypeOfferAccepted  NotificationType = "offer_accepted"
+	NotificationTypeReviewReceived NotificationType = "review_received"
+)
+
+var NotificationTypes = []NotificationType{
+	NotificationTypeNewMessage,
+	NotificationTypeNewOffer,
+	NotificationTypeOfferAccepted,
+	NotificationTypeReviewReceived,
+}
+
+// Notification is an entry in a user's notification center. The optional
+// references point at whatever the notification is about.
+type Notification struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Type      NotificationType
+	ActorID   *uuid.UUID
+	RequestID *uuid.UUID
+	ChatID    *uuid.UUID
+	MessageID *uuid.UUID
+	// EventID is the domain event that produced the notification; a user
+	// gets at most one notification per event.
+	EventID uuid.UUID
+	// InApp is false when the user turned in-app notifications of this type
+	// off. Such notifications stay out of the feed but are still recorded so
+	// other channels can tell the event was handled.
+	InApp     bool
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+// Cursor returns the notification's position in the feed. Notifications page
+// with the same cursor format as chat messages.
+func (n Notification) Cursor() MessageCursor {
+	return MessageCursor{CreatedAt: n.CreatedAt, ID: n.ID}
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp NotificationChannel = "in_app"
+	NotificationChannelPush  NotificationChannel = "push"
+	NotificationChannelSMS   NotificationChannel = "sms"
+)
+
+var NotificationChannels = []NotificationChannel{
+	NotificationChannelInApp,
+	NotificationChannelPush,
+	NotificationChannelSMS,
+}
+
+// NotificationPreference turns one type of notification on or off for one
+// channel.
+type NotificationPreference struct {
+	Channel NotificationChannel
+	Type    NotificationType
+	Enabled bool
+}
+
+// QuietHours silence interruptive channels between Start and End, given in
+// minutes after local midnight. End before Start spans midnight; equal Start
+// and End mean no quiet hours.
+type QuietHours struct {
+	Enabled  bool
+	Start    int
+	End      int
+	TimeZone string
+}
+
+// Active reports whether at falls within the quiet hours. An unknown time
+// zone is treated as UTC.
+func (q QuietHours) Active(at time.Time) bool {
+	if !q.Enabled || q.Start == q.End {
+		return false
+	}
+
+	location, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	local := at.In(location)
+	minute := local.Hour()*60 + local.Minute()
+
+	if q.Start < q.End {
+		return minute >= q.Start && minute < q.End
+	}
+	return minute >= q.Start || minute < q.End
+}
+
+// FormatClock renders minutes after midnight as HH:MM.
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// NotificationSettings are a user's delivery preferences.
+type NotificationSettings struct {
+	UserID uuid.UUID
+	// PushPreviews includes message text in push notifications.
+	PushPreviews bool
+	QuietHours   QuietHours
+	// Preferences holds the user's explicit choices; combinations without
+	// one use the channel default.
+	Preferences []NotificationPreference
+	UpdatedAt   time.Time
+}
+
+// DefaultNotificationSettings applies to users who never changed their
+// settings.
+func DefaultNotificationSettings(userID uuid.UUID) NotificationSettings {
+	return NotificationSettings{
+		UserID:       userID,
+		PushPreviews: true,
+		QuietHours:   QuietHours{Start: 22 * 60, End: 7 * 60, TimeZone: "UTC"},
+	}
+}
+
+// Enabled reports whether the user wants notifications of type t over
+// channel. SMS is opt-in; the other channels are on by default.
+func (s NotificationSettings) Enabled(channel NotificationChannel, t NotificationType) bool {
+	for _, pref := range s.Preferences {
+		if pref.Channel == channel && pref.Type == t {
+			return pref.Enabled
+		}
+	}
+	return channel != NotificationChannelSMS
+}
+
+// Allows reports whether a notification of type t may go out over channel
+// at the given time. Push and SMS are suppressed during quiet hours and not
+// sent later; the in-app feed is not interruptive and always fills.
+func (s NotificationSettings) Allows(channel NotificationChannel, t NotificationType, at time.Time) bool {
+	if !s.Enabled(channel, t) {
+		return false
+	}
+	return channel == NotificationChannelInApp || !s.QuietHours.Active(at)
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestQuietHoursActive(t *testing.T) {
+	// 21:30 UTC is 02:30 the next day in Tashkent (UTC+5).
+	evening := time.Date(2026, 3, 10, 21, 30, 0, 0, time.UTC)
+	noon := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	morning := time.Date(2026, 3, 10, 6, 59, 0, 0, time.UTC)
+	seven := time.Date(2026, 3, 10, 7, 0, 0, 0, time.UTC)
+
+	overnight := QuietHours{Enabled: true, Start: 22 * 60, End: 7 * 60, TimeZone: "UTC"}
+	daytime := QuietHours{Enabled: true, Start: 9 * 60, End: 17 * 60, TimeZone: "UTC"}
+	tashkent := QuietHours{Enabled: true, Start: 1 * 60, End: 3 * 60, TimeZone: "Asia/Tashkent"}
+	unknownZone := QuietHours{Enabled: true, Start: 21 * 60, End: 22 * 60, TimeZone: "Mars/Olympus_Mons"}
+
+	tests := []struct {
+		name  string
+		quiet QuietHours
+		at    time.Time
+		want  bool
+	}{
+		{"disabled", QuietHours{Start: 22 * 60, End: 7 * 60, TimeZone: "UTC"}, evening, false},
+		{"overnight before midnight", QuietHours{Enabled: true, Start: 21 * 60, End: 7 * 60, TimeZone: "UTC"}, evening, true},
+		{"overnight after midnight", overnight, morning, true},
+		{"overnight end is exclusive", overnight, seven, false},
+		{"overnight outside", overnight, noon, false},
+		{"same-day inside", daytime, noon, true},
+		{"same-day outside", daytime, evening, false},
+		{"start equals end", QuietHours{Enabled: true, Start: 12 * 60, End: 12 * 60, TimeZone: "UTC"}, noon, false},
+		{"local time zone", tashkent, evening, true},
+		{"local time zone outside", tashkent, noon, false},
+		{"unknown time zone falls back to UTC", unknownZone, evening, true},
+		{"unknown time zone outside UTC range", unknownZone, noon, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.quiet.Active(tt.at); got != tt.want {
+				t.Errorf("Active(%s) = %v, want %v", tt.at.Format(time.RFC3339), got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is a domain event waiting in the outbox for dispatch.
+type OutboxEvent struct {
+	ID        uuid.UUID
+	Type      string
+	Payload   []byte
+	Attempts  int
+	CreatedAt time.Time
+}
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReadCursor marks the newest message a participant has read in a chat; every
+// earlier message in the chat counts as read too.
+type ReadCursor struct {
+	ChatID            uuid.UUID
+	UserID            uuid.UUID
+	LastReadMessageID uuid.UUID
+	ReadAt            time.Time
+}
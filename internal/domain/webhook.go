@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is a partner endpoint that receives the listed domain events.
+type Webhook struct {
+	ID         uuid.UUID
+	URL        string
+	Secret     string
+	EventTypes []string
+	Active     bool
+	CreatedBy  uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (w Webhook) Subscribes(eventType string) bool {
+	return w.Active && slices.Contains(w.EventTypes, eventType)
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event queued for one webhook, and the log of how
+// sending it went.
+type WebhookDelivery struct {
+	ID        uuid.UUID
+	WebhookID uuid.UUID
+	EventID   uuid.UUID
+	EventType string
+	// Payload is the exact body sent to the endpoint.
+	Payload       []byte
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	// ResponseStatus is the HTTP status of the last attempt, if it got one.
+	ResponseStatus *int
+	LastError      *string
+	CreatedAt      time.Time
+	CompletedAt    *time.Time
+}
@@ -0,0 +1,48 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// Handler reacts to dispatched events. Name must be unique and stable: it is
+// stored to remember which handlers already processed an event.
+type Handler interface {
+	Name() string
+	Handle(ctx context.Context, event Event) error
+}
+
+// Bus routes events to the handlers subscribed to their type.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	all      []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers handler for the given event types, or for every event
+// when none are given.
+func (b *Bus) Subscribe(handler Handler, eventTypes ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(eventTypes) == 0 {
+		b.all = append(b.all, handler)
+		return
+	}
+	for _, eventType := range eventTypes {
+		b.handlers[eventType] = append(b.handlers[eventType], handler)
+	}
+}
+
+func (b *Bus) handlersFor(eventType string) []Handler {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	handlers := make([]Handler, 0, len(b.all)+len(b.handlers[eventType]))
+	handlers = append(handlers, b.all...)
+	return append(handlers, b.handlers[eventType]...)
+}
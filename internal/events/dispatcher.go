@@ -0,0 +1,151 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/logger"
+	"github.com/barzurustami/bozor/internal/repository"
+	"go.uber.org/zap"
+)
+
+const maxRetryDelay = time.Hour
+
+type DispatcherConfig struct {
+	PollInterval time.Duration
+	BatchSize    int32
+	// Lease is how long a claimed event stays hidden from other dispatchers
+	// while its handlers run.
+	Lease       time.Duration
+	MaxAttempts int
+}
+
+// Dispatcher polls the outbox and hands events to the bus with at-least-once
+// semantics: an event is retried with backoff until every handler succeeds
+// or MaxAttempts is reached.
+type Dispatcher struct {
+	outbox repository.OutboxRepository
+	bus    *Bus
+	cfg    DispatcherConfig
+}
+
+func NewDispatcher(outbox repository.OutboxRepository, bus *Bus, cfg DispatcherConfig) *Dispatcher {
+	return &Dispatcher{outbox: outbox, bus: bus, cfg: cfg}
+}
+
+// Run dispatches events until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			count, err := d.DispatchOnce(ctx)
+			if err != nil && ctx.Err() == nil {
+				logger.FromContext(ctx).Error("outbox dispatch failed", zap.Error(err))
+			}
+			// A full batch suggests a backlog, so keep draining.
+			if err != nil || count < int(d.cfg.BatchSize) {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce claims one batch of due events and delivers it, returning the
+// number of events claimed.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	claimed, err := d.outbox.Claim(ctx, now, now.Add(d.cfg.Lease), d.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range claimed {
+		if err := d.dispatch(ctx, event); err != nil {
+			return len(claimed), err
+		}
+	}
+	return len(claimed), nil
+}
+
+// dispatch runs the event's handlers and records the outcome. Handler errors
+// are stored on the event; only outbox bookkeeping errors are returned.
+func (d *Dispatcher) dispatch(ctx context.Context, record domain.OutboxEvent) error {
+	event := Event{
+		ID:        record.ID,
+		Type:      record.Type,
+		Payload:   record.Payload,
+		CreatedAt: record.CreatedAt,
+	}
+	log := logger.FromContext(ctx).With(
+		zap.String("event_id", event.ID.String()),
+		zap.String("event_type", event.Type),
+	)
+
+	var failures []string
+	for _, handler := range d.bus.handlersFor(event.Type) {
+		processed, err := d.outbox.IsProcessed(ctx, event.ID, handler.Name())
+		if err != nil {
+			return err
+		}
+		if processed {
+			continue
+		}
+
+		if err := d.handle(ctx, handler, event); err != nil {
+			log.Warn("event handler failed", zap.String("handler", handler.Name()), zap.Error(err))
+			failures = append(failures, fmt.Sprintf("%s: %v", handler.Name(), err))
+			continue
+		}
+
+		if err := d.outbox.MarkProcessed(ctx, event.ID, handler.Name(), time.Now().UTC()); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().UTC()
+	if len(failures) == 0 {
+		return d.outbox.MarkDispatched(ctx, event.ID, now)
+	}
+
+	lastError := strings.Join(failures, "; ")
+	if record.Attempts >= d.cfg.MaxAttempts {
+		log.Error("event dispatch gave up", zap.Int("attempts", record.Attempts), zap.String("error", lastError))
+		return d.outbox.MarkFailed(ctx, event.ID, now, lastError)
+	}
+	return d.outbox.Reschedule(ctx, event.ID, now.Add(retryDelay(d.cfg.PollInterval, record.Attempts)), lastError)
+}
+
+// handle runs one handler, turning a panic into an error so one bad handler
+// cannot stop the dispatcher.
+func (d *Dispatcher) handle(ctx context.Context, handler Handler, event Event) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = errors.New(fmt.Sprint("panic: ", recovered))
+		}
+	}()
+	return handler.Handle(ctx, event)
+}
+
+// retryDelay doubles the base delay per attempt, capped at maxRetryDelay.
+func retryDelay(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,57 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types. Payloads are the structs below, encoded as JSON.
+const (
+	TypeRequestCreated     = "request.created"
+	TypeRequestPhotosAdded = "request.photos_added"
+	TypeChatCreated        = "chat.created"
+	TypeChatMessageSent    = "chat.message_sent"
+)
+
+type RequestCreated struct {
+	RequestID  uuid.UUID `json:"request_id"`
+	CustomerID uuid.UUID `json:"customer_id"`
+	Title      string    `json:"title"`
+}
+
+type RequestPhotosAdded struct {
+	RequestID  uuid.UUID   `json:"request_id"`
+	UploaderID uuid.UUID   `json:"uploader_id"`
+	PhotoIDs   []uuid.UUID `json:"photo_ids"`
+}
+
+type ChatCreated struct {
+	ChatID      uuid.UUID `json:"chat_id"`
+	RequestID   uuid.UUID `json:"request_id"`
+	CreatorID   uuid.UUID `json:"creator_id"`
+	InitiatorID uuid.UUID `json:"initiator_id"`
+}
+
+type ChatMessageSent struct {
+	MessageID uuid.UUID `json:"message_id"`
+	ChatID    uuid.UUID `json:"chat_id"`
+	SenderID  uuid.UUID `json:"sender_id"`
+	Kind      string    `json:"kind"`
+}
+
+// Event is a dispatched domain event. ID doubles as the idempotency key:
+// delivery is at-least-once, so handlers with external side effects should
+// dedupe on it.
+type Event struct {
+	ID        uuid.UUID
+	Type      string
+	Payload   json.RawMessage
+	CreatedAt time.Time
+}
+
+// Decode unmarshals the payload into v.
+func (e Event) Decode(v any) error {
+	return json.Unmarshal(e.Payload, v)
+}
@@ -0,0 +1,29 @@
+package events
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/logger"
+	"go.uber.org/zap"
+)
+
+// LogHandler records every dispatched event in the application log.
+type LogHandler struct{}
+
+func NewLogHandler() *LogHandler {
+	return &LogHandler{}
+}
+
+func (h *LogHandler) Name() string {
+	return "log"
+}
+
+func (h *LogHandler) Handle(ctx context.Context, event Event) error {
+	logger.FromContext(ctx).Info(
+		"domain event",
+		zap.String("event_id", event.ID.String()),
+		zap.String("event_type", event.Type),
+		zap.ByteString("payload", event.Payload),
+	)
+	return nil
+}
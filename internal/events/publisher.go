@@ -0,0 +1,35 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/google/uuid"
+)
+
+type Publisher struct {
+	outbox repository.OutboxRepository
+}
+
+func NewPublisher(outbox repository.OutboxRepository) *Publisher {
+	return &Publisher{outbox: outbox}
+}
+
+// Publish writes the event to the outbox. Call it with the ctx of the
+// transaction making the change so the event commits or rolls back with it.
+func (p *Publisher) Publish(ctx context.Context, eventType string, payload any) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return p.outbox.Add(ctx, &domain.OutboxEvent{
+		ID:        uuid.New(),
+		Type:      eventType,
+		Payload:   encoded,
+		CreatedAt: time.Now().UTC(),
+	})
+}
@@ -0,0 +1,133 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/barzurustami/bozor/internal/logger"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/zap"
+)
+
+const (
+	// touchInterval limits how often hits served from memory refresh the
+	// stored last use.
+	touchInterval = time.Hour
+	pruneInterval = 10 * time.Minute
+)
+
+// PersistedQueryOptions bounds what clients can register. Registration needs
+// no login, so stored documents are capped in size, age and number.
+type PersistedQueryOptions struct {
+	CacheSize     int
+	MaxQueryBytes int
+	MaxStored     int
+	TTL           time.Duration
+}
+
+// PersistedQueryCache keeps automatic persisted queries in a local LRU backed
+// by Postgres, so a hash registered on one instance resolves on all of them
+// and survives restarts.
+//
+// The APQ extension hands documents to Add before they are parsed, so Add
+// stores nothing. The cache is also a server extension that stores a
+// document once it has passed validation and the complexity and depth
+// limits; it must be registered after those.
+type PersistedQueryCache struct {
+	local *lru.LRU[persistedQuery]
+	store repository.PersistedQueryRepository
+	opts  PersistedQueryOptions
+}
+
+type persistedQuery struct {
+	query     string
+	touchedAt time.Time
+}
+
+var _ interface {
+	graphql.Cache[string]
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = (*PersistedQueryCache)(nil)
+
+func NewPersistedQueryCache(store repository.PersistedQueryRepository, opts PersistedQueryOptions) *PersistedQueryCache {
+	return &PersistedQueryCache{local: lru.New[persistedQuery](opts.CacheSize), store: store, opts: opts}
+}
+
+func (c *PersistedQueryCache) Get(ctx context.Context, hash string) (string, bool) {
+	now := time.Now().UTC()
+	if entry, ok := c.local.Get(ctx, hash); ok {
+		if now.Sub(entry.touchedAt) < touchInterval {
+			return entry.query, true
+		}
+	}
+
+	query, err := c.store.Use(ctx, hash, now)
+	if err != nil {
+		// A miss makes the client resend the full document, so a store
+		// failure only costs a round trip.
+		return "", false
+	}
+	c.local.Add(ctx, hash, persistedQuery{query: query, touchedAt: now})
+	return query, true
+}
+
+// Add is called by the APQ extension with a document whose hash matches but
+// which has not been parsed yet; MutateOperationContext stores it instead.
+func (c *PersistedQueryCache) Add(context.Context, string, string) {}
+
+func (c *PersistedQueryCache) ExtensionName() string {
+	return "PersistedQueryStore"
+}
+
+func (c *PersistedQueryCache) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// MutateOperationContext only runs for operations that parsed, validated and
+// passed every earlier operation context mutator, so only usable documents
+// are stored.
+func (c *PersistedQueryCache) MutateOperationContext(ctx context.Context, oc *graphql.OperationContext) *gqlerror.Error {
+	stats := extension.GetApqStats(ctx)
+	if stats == nil || !stats.SentQuery {
+		return nil
+	}
+	if len(oc.RawQuery) > c.opts.MaxQueryBytes {
+		// The operation still runs; clients just keep sending the document.
+		return nil
+	}
+
+	now := time.Now().UTC()
+	c.local.Add(ctx, stats.Hash, persistedQuery{query: oc.RawQuery, touchedAt: now})
+	if err := c.store.Save(ctx, stats.Hash, oc.RawQuery, now); err != nil {
+		logger.FromContext(ctx).Warn("persisted query save failed", zap.String("hash", stats.Hash), zap.Error(err))
+	}
+	return nil
+}
+
+// Run prunes stored documents until ctx is done. Every instance may run it;
+// pruning is idempotent.
+func (c *PersistedQueryCache) Run(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		pruned, err := c.store.Prune(ctx, time.Now().UTC().Add(-c.opts.TTL), c.opts.MaxStored)
+		if err != nil && ctx.Err() == nil {
+			logger.FromContext(ctx).Error("persisted query prune failed", zap.Error(err))
+		}
+		if pruned > 0 {
+			logger.FromContext(ctx).Info("persisted queries pruned", zap.Int64("count", pruned))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const errDepthLimit = "DEPTH_LIMIT_EXCEEDED"
+
+// DepthLimit rejects operations whose selections nest deeper than Limit.
+// Introspection fields are not counted; the introspection extension decides
+// whether they are allowed at all.
+type DepthLimit struct {
+	Limit int
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = DepthLimit{}
+
+func init() {
+	errcode.RegisterErrorType(errDepthLimit, errcode.KindProtocol)
+}
+
+func (DepthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (DepthLimit) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d DepthLimit) MutateOperationContext(ctx context.Context, oc *graphql.OperationContext) *gqlerror.Error {
+	if oc.Operation == nil {
+		return nil
+	}
+
+	depth := selectionDepth(oc.Operation.SelectionSet, make(map[string]bool))
+	if depth > d.Limit {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.Limit)
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+	return nil
+}
+
+// selectionDepth returns the deepest field nesting in set. Fragment spreads
+// and inline fragments add no depth of their own; visiting guards against
+// fragment cycles, which validation rejects anyway.
+func selectionDepth(set ast.SelectionSet, visiting map[string]bool) int {
+	deepest := 0
+	for _, selection := range set {
+		var depth int
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name, "__") {
+				continue
+			}
+			depth = 1 + selectionDepth(sel.SelectionSet, visiting)
+		case *ast.InlineFragment:
+			depth = selectionDepth(sel.SelectionSet, visiting)
+		case *ast.FragmentSpread:
+			if sel.Definition == nil || visiting[sel.Name] {
+				continue
+			}
+			visiting[sel.Name] = true
+			depth = selectionDepth(sel.Definition.SelectionSet, visiting)
+			delete(visiting, sel.Name)
+		}
+		if depth > deepest {
+			deepest = depth
+		}
+	}
+	return deepest
+}
@@ -0,0 +1,50 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/logger"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/zap"
+)
+
+const internalErrorMessage = "internal server error"
+
+// NewErrorPresenter sets extensions.code on every error. Application errors
+// keep their message; anything else is reported as INTERNAL, logged, and has
+// its message replaced unless exposeInternal is set.
+func NewErrorPresenter(exposeInternal bool) graphql.ErrorPresenterFunc {
+	return func(ctx context.Context, err error) *gqlerror.Error {
+		gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = make(map[string]any)
+		}
+
+		var appErr *apperr.Error
+		switch {
+		case errors.As(err, &appErr):
+			gqlErr.Message = appErr.Message
+			gqlErr.Extensions["code"] = appErr.Code
+			if len(appErr.Fields) > 0 {
+				gqlErr.Extensions["fields"] = appErr.Fields
+			}
+		case gqlErr.Err == nil && gqlErr.Extensions["code"] != nil:
+			// Parse and validation errors produced by gqlgen already carry a
+			// code such as GRAPHQL_VALIDATION_FAILED.
+		default:
+			logger.FromContext(ctx).Error("graphql internal error",
+				zap.String("path", gqlErr.Path.String()),
+				zap.Error(err),
+			)
+			gqlErr.Extensions["code"] = apperr.CodeInternal
+			if !exposeInternal {
+				gqlErr.Message = internalErrorMessage
+			}
+		}
+
+		return gqlErr
+	}
+}
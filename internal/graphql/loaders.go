@@ -0,0 +1,31 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/barzurustami/bozor/internal/dataloader"
+)
+
+// Loaders attaches fresh data loaders to every response. Subscriptions get a
+// new set per event, so cached rows never go stale across events.
+type Loaders struct {
+	Sources dataloader.Sources
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+} = Loaders{}
+
+func (Loaders) ExtensionName() string {
+	return "Loaders"
+}
+
+func (Loaders) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (l Loaders) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	return next(dataloader.WithLoaders(ctx, dataloader.NewLoaders(ctx, l.Sources)))
+}
@@ -0,0 +1,102 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/barzurustami/bozor/internal/metrics"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	maxOperationLabels      = 200
+	maxOperationLabelLength = 64
+	otherOperationLabel     = "other"
+)
+
+// operationLabels bounds the operation names used as metric labels. Clients
+// choose the names, so only the first maxOperationLabels distinct ones get a
+// series of their own and the rest share "other".
+var operationLabels = &labelSet{seen: make(map[string]struct{})}
+
+type labelSet struct {
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+func (l *labelSet) label(name string) string {
+	if len(name) > maxOperationLabelLength {
+		return otherOperationLabel
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.seen[name]; ok {
+		return name
+	}
+	if len(l.seen) >= maxOperationLabels {
+		return otherOperationLabel
+	}
+	l.seen[name] = struct{}{}
+	return name
+}
+
+// Metrics records operation latency and resolver errors.
+type Metrics struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = Metrics{}
+
+func (Metrics) ExtensionName() string {
+	return "Metrics"
+}
+
+func (Metrics) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (Metrics) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	resp := next(ctx)
+	if !graphql.HasOperationContext(ctx) {
+		return resp
+	}
+
+	oc := graphql.GetOperationContext(ctx)
+	// Subscriptions produce a response per event, so their latency is not
+	// meaningful here; open subscriptions are tracked by the chat service.
+	if oc.Operation == nil || oc.Operation.Operation == ast.Subscription {
+		return resp
+	}
+
+	metrics.GraphQLOperationDuration.
+		WithLabelValues(operationLabels.label(operationName(oc)), string(oc.Operation.Operation)).
+		Observe(time.Since(oc.Stats.OperationStart).Seconds())
+
+	return resp
+}
+
+func (Metrics) InterceptField(ctx context.Context, next graphql.Resolver) (any, error) {
+	res, err := next(ctx)
+	if err != nil {
+		fc := graphql.GetFieldContext(ctx)
+		metrics.GraphQLResolverErrors.
+			WithLabelValues(operationLabels.label(operationName(graphql.GetOperationContext(ctx))), fc.Object+"."+fc.Field.Name).
+			Inc()
+	}
+	return res, err
+}
+
+func operationName(oc *graphql.OperationContext) string {
+	if oc.OperationName != "" {
+		return oc.OperationName
+	}
+	if oc.Operation != nil && oc.Operation.Name != "" {
+		return oc.Operation.Name
+	}
+	return "anonymous"
+}
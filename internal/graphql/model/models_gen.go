@@ -3,31 +3,72 @@
 package model
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+
 	"github.com/99designs/gqlgen/graphql"
 )
 
+type AttachmentInput struct {
+	File       graphql.Upload `json:"file"`
+	DurationMs *int           `json:"durationMs,omitempty"`
+}
+
 type AuthPayload struct {
 	User   *User      `json:"user"`
 	Tokens *TokenPair `json:"tokens"`
 }
 
 type Chat struct {
-	ID            string `json:"id"`
-	RequestID     string `json:"requestId"`
-	CreatorID     string `json:"creatorId"`
-	InitiatorID   string `json:"initiatorId"`
-	CreatedAt     Time   `json:"createdAt"`
-	LastMessageAt *Time  `json:"lastMessageAt,omitempty"`
+	ID            string             `json:"id"`
+	RequestID     string             `json:"requestId"`
+	CreatorID     string             `json:"creatorId"`
+	InitiatorID   string             `json:"initiatorId"`
+	CreatedAt     Time               `json:"createdAt"`
+	LastMessageAt *Time              `json:"lastMessageAt,omitempty"`
+	ArchivedAt    *Time              `json:"archivedAt,omitempty"`
+	MutedUntil    *Time              `json:"mutedUntil,omitempty"`
+	PinnedAt      *Time              `json:"pinnedAt,omitempty"`
+	UnreadCount   int                `json:"unreadCount"`
+	Participants  []*ChatParticipant `json:"participants"`
+	Request       *JobRequest        `json:"request"`
+	Creator       *User              `json:"creator"`
+}
+
+type ChatFilter struct {
+	// Pass null to list archived and active chats together.
+	Archived *bool `json:"archived,omitempty"`
 }
 
 type ChatMessage struct {
-	ID        string  `json:"id"`
-	ChatID    string  `json:"chatId"`
-	SenderID  string  `json:"senderId"`
-	Text      *string `json:"text,omitempty"`
-	Photo     *string `json:"photo,omitempty"`
-	CreatedAt Time    `json:"createdAt"`
-	ReadAt    *Time   `json:"readAt,omitempty"`
+	ID          string               `json:"id"`
+	ChatID      string               `json:"chatId"`
+	SenderID    string               `json:"senderId"`
+	Sender      *User                `json:"sender"`
+	Text        *string              `json:"text,omitempty"`
+	Photo       *string              `json:"photo,omitempty"`
+	CreatedAt   Time                 `json:"createdAt"`
+	ReadAt      *Time                `json:"readAt,omitempty"`
+	DeliveredAt *Time                `json:"deliveredAt,omitempty"`
+	EditedAt    *Time                `json:"editedAt,omitempty"`
+	DeletedAt   *Time                `json:"deletedAt,omitempty"`
+	ReplyToID   *string              `json:"replyToId,omitempty"`
+	ReplyTo     *ChatMessage         `json:"replyTo,omitempty"`
+	Reactions   []*MessageReaction   `json:"reactions"`
+	Attachments []*MessageAttachment `json:"attachments"`
+	Kind        ChatMessageKind      `json:"kind"`
+	// True while the message awaits moderator review; only its sender can see it.
+	Held bool `json:"held"`
+	// Set for system messages; senderId is the user whose action produced the event.
+	System *SystemPayload `json:"system,omitempty"`
+}
+
+type ChatParticipant struct {
+	UserID   string              `json:"userId"`
+	Role     ChatParticipantRole `json:"role"`
+	JoinedAt Time                `json:"joinedAt"`
 }
 
 type CreateRequestInput struct {
@@ -36,6 +77,24 @@ type CreateRequestInput struct {
 	Address     *string `json:"address,omitempty"`
 }
 
+type CreateWebhookInput struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
+type Device struct {
+	ID        string         `json:"id"`
+	Platform  DevicePlatform `json:"platform"`
+	CreatedAt Time           `json:"createdAt"`
+}
+
+type HeldMessage struct {
+	Message *ChatMessage `json:"message"`
+	// The spam heuristics that held it, e.g. link or duplicate.
+	Reasons []string `json:"reasons"`
+}
+
 type JobRequest struct {
 	ID          string   `json:"id"`
 	Title       string   `json:"title"`
@@ -50,9 +109,98 @@ type LoginInput struct {
 	Code  string `json:"code"`
 }
 
+type MessageAttachment struct {
+	ID         string `json:"id"`
+	Path       string `json:"path"`
+	MimeType   string `json:"mimeType"`
+	Size       int    `json:"size"`
+	FileName   string `json:"fileName"`
+	DurationMs *int   `json:"durationMs,omitempty"`
+	CreatedAt  Time   `json:"createdAt"`
+}
+
+type MessageReaction struct {
+	MessageID string `json:"messageId"`
+	UserID    string `json:"userId"`
+	Emoji     string `json:"emoji"`
+	CreatedAt Time   `json:"createdAt"`
+}
+
+type MessageSearchHit struct {
+	Message *ChatMessage `json:"message"`
+	// HTML-escaped excerpt of the message text with matched terms wrapped in <mark> tags, safe to render as markup.
+	Snippet string `json:"snippet"`
+	// Pass as chatMessages(around:) to open the chat at this message.
+	Cursor string `json:"cursor"`
+}
+
+type MessageSearchResult struct {
+	Hits      []*MessageSearchHit `json:"hits"`
+	EndCursor *string             `json:"endCursor,omitempty"`
+	HasMore   bool                `json:"hasMore"`
+}
+
+type ModerationReport struct {
+	ID        string       `json:"id"`
+	ChatID    *string      `json:"chatId,omitempty"`
+	MessageID *string      `json:"messageId,omitempty"`
+	Status    ReportStatus `json:"status"`
+	CreatedAt Time         `json:"createdAt"`
+}
+
 type Mutation struct {
 }
 
+type Notification struct {
+	ID   string           `json:"id"`
+	Type NotificationType `json:"type"`
+	// The user whose action produced the notification.
+	ActorID   *string     `json:"actorId,omitempty"`
+	Actor     *User       `json:"actor,omitempty"`
+	RequestID *string     `json:"requestId,omitempty"`
+	Request   *JobRequest `json:"request,omitempty"`
+	ChatID    *string     `json:"chatId,omitempty"`
+	MessageID *string     `json:"messageId,omitempty"`
+	ReadAt    *Time       `json:"readAt,omitempty"`
+	CreatedAt Time        `json:"createdAt"`
+}
+
+type NotificationPage struct {
+	Notifications []*Notification `json:"notifications"`
+	EndCursor     *string         `json:"endCursor,omitempty"`
+	HasMore       bool            `json:"hasMore"`
+	UnreadCount   int             `json:"unreadCount"`
+}
+
+type NotificationPreference struct {
+	Channel NotificationChannel `json:"channel"`
+	Type    NotificationType    `json:"type"`
+	Enabled bool                `json:"enabled"`
+}
+
+type NotificationPreferenceInput struct {
+	Channel NotificationChannel `json:"channel"`
+	Type    NotificationType    `json:"type"`
+	Enabled bool                `json:"enabled"`
+}
+
+type NotificationSettings struct {
+	// Include message text in push notifications.
+	PushPreviews bool `json:"pushPreviews"`
+	// Every channel and type combination. SMS is off unless enabled.
+	Preferences []*NotificationPreference `json:"preferences"`
+	// Push and SMS alerts are suppressed, not delayed, during quiet hours; the in-app feed still fills.
+	QuietHours *QuietHours `json:"quietHours"`
+}
+
+// Fields left null keep their current value.
+type NotificationSettingsInput struct {
+	PushPreviews *bool `json:"pushPreviews,omitempty"`
+	// Listed combinations are updated; the others keep their value.
+	Preferences []*NotificationPreferenceInput `json:"preferences,omitempty"`
+	QuietHours  *QuietHoursInput               `json:"quietHours,omitempty"`
+}
+
 type Photo struct {
 	ID        string `json:"id"`
 	Path      string `json:"path"`
@@ -77,20 +225,50 @@ type ProfileInput struct {
 type Query struct {
 }
 
+type QuietHours struct {
+	Enabled  bool   `json:"enabled"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	TimeZone string `json:"timeZone"`
+}
+
+type QuietHoursInput struct {
+	Enabled bool `json:"enabled"`
+	// Local time as HH:MM.
+	Start string `json:"start"`
+	// Local time as HH:MM; earlier than start to span midnight.
+	End string `json:"end"`
+	// IANA time zone, e.g. Asia/Tashkent.
+	TimeZone string `json:"timeZone"`
+}
+
+type ReactionEvent struct {
+	ChatID   string           `json:"chatId"`
+	Reaction *MessageReaction `json:"reaction"`
+	Added    bool             `json:"added"`
+}
+
 type RegisterInput struct {
 	Phone string `json:"phone"`
 	Code  string `json:"code"`
 }
 
 type SendMessageInput struct {
-	ChatID string          `json:"chatId"`
-	Text   *string         `json:"text,omitempty"`
-	File   *graphql.Upload `json:"file,omitempty"`
+	ChatID      string             `json:"chatId"`
+	Text        *string            `json:"text,omitempty"`
+	File        *graphql.Upload    `json:"file,omitempty"`
+	Attachments []*AttachmentInput `json:"attachments,omitempty"`
+	ReplyToID   *string            `json:"replyToId,omitempty"`
 }
 
 type Subscription struct {
 }
 
+type SystemPayload struct {
+	Event SystemEvent    `json:"event"`
+	Data  map[string]any `json:"data,omitempty"`
+}
+
 type TokenPair struct {
 	AccessToken      string `json:"accessToken"`
 	RefreshToken     string `json:"refreshToken"`
@@ -98,13 +276,555 @@ type TokenPair struct {
 	RefreshExpiresAt Time   `json:"refreshExpiresAt"`
 }
 
+// Fields left null keep their current value.
+type UpdateWebhookInput struct {
+	URL        *string  `json:"url,omitempty"`
+	EventTypes []string `json:"eventTypes,omitempty"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
 type UploadPhotosInput struct {
 	RequestID string            `json:"requestId"`
 	Files     []*graphql.Upload `json:"files"`
 }
 
-type User struct {
-	ID      string   `json:"id"`
-	Phone   string   `json:"phone"`
-	Profile *Profile `json:"profile,omitempty"`
+type Webhook struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Active     bool     `json:"active"`
+	CreatedAt  Time     `json:"createdAt"`
+	UpdatedAt  Time     `json:"updatedAt"`
+}
+
+type WebhookDelivery struct {
+	ID        string                `json:"id"`
+	EventID   string                `json:"eventId"`
+	EventType string                `json:"eventType"`
+	Payload   string                `json:"payload"`
+	Status    WebhookDeliveryStatus `json:"status"`
+	Attempts  int                   `json:"attempts"`
+	// HTTP status of the last attempt, if the endpoint answered.
+	ResponseStatus *int    `json:"responseStatus,omitempty"`
+	LastError      *string `json:"lastError,omitempty"`
+	NextAttemptAt  *Time   `json:"nextAttemptAt,omitempty"`
+	CreatedAt      Time    `json:"createdAt"`
+	CompletedAt    *Time   `json:"completedAt,omitempty"`
+}
+
+type WebhookWithSecret struct {
+	Webhook *Webhook `json:"webhook"`
+	// Verify the X-Bozor-Signature header with this secret.
+	Secret string `json:"secret"`
+}
+
+type ChatMessageKind string
+
+const (
+	ChatMessageKindUser   ChatMessageKind = "USER"
+	ChatMessageKindSystem ChatMessageKind = "SYSTEM"
+)
+
+var AllChatMessageKind = []ChatMessageKind{
+	ChatMessageKindUser,
+	ChatMessageKindSystem,
+}
+
+func (e ChatMessageKind) IsValid() bool {
+	switch e {
+	case ChatMessageKindUser, ChatMessageKindSystem:
+		return true
+	}
+	return false
+}
+
+func (e ChatMessageKind) String() string {
+	return string(e)
+}
+
+func (e *ChatMessageKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ChatMessageKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ChatMessageKind", str)
+	}
+	return nil
+}
+
+func (e ChatMessageKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ChatMessageKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ChatMessageKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ChatParticipantRole string
+
+const (
+	ChatParticipantRoleOwner  ChatParticipantRole = "OWNER"
+	ChatParticipantRoleMember ChatParticipantRole = "MEMBER"
+)
+
+var AllChatParticipantRole = []ChatParticipantRole{
+	ChatParticipantRoleOwner,
+	ChatParticipantRoleMember,
+}
+
+func (e ChatParticipantRole) IsValid() bool {
+	switch e {
+	case ChatParticipantRoleOwner, ChatParticipantRoleMember:
+		return true
+	}
+	return false
+}
+
+func (e ChatParticipantRole) String() string {
+	return string(e)
+}
+
+func (e *ChatParticipantRole) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ChatParticipantRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ChatParticipantRole", str)
+	}
+	return nil
+}
+
+func (e ChatParticipantRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ChatParticipantRole) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ChatParticipantRole) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type DevicePlatform string
+
+const (
+	DevicePlatformIos     DevicePlatform = "IOS"
+	DevicePlatformAndroid DevicePlatform = "ANDROID"
+	DevicePlatformWeb     DevicePlatform = "WEB"
+)
+
+var AllDevicePlatform = []DevicePlatform{
+	DevicePlatformIos,
+	DevicePlatformAndroid,
+	DevicePlatformWeb,
+}
+
+func (e DevicePlatform) IsValid() bool {
+	switch e {
+	case DevicePlatformIos, DevicePlatformAndroid, DevicePlatformWeb:
+		return true
+	}
+	return false
+}
+
+func (e DevicePlatform) String() string {
+	return string(e)
+}
+
+func (e *DevicePlatform) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DevicePlatform(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DevicePlatform", str)
+	}
+	return nil
+}
+
+func (e DevicePlatform) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DevicePlatform) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DevicePlatform) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type MessageDeleteScope string
+
+const (
+	MessageDeleteScopeMe       MessageDeleteScope = "ME"
+	MessageDeleteScopeEveryone MessageDeleteScope = "EVERYONE"
+)
+
+var AllMessageDeleteScope = []MessageDeleteScope{
+	MessageDeleteScopeMe,
+	MessageDeleteScopeEveryone,
+}
+
+func (e MessageDeleteScope) IsValid() bool {
+	switch e {
+	case MessageDeleteScopeMe, MessageDeleteScopeEveryone:
+		return true
+	}
+	return false
+}
+
+func (e MessageDeleteScope) String() string {
+	return string(e)
+}
+
+func (e *MessageDeleteScope) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MessageDeleteScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MessageDeleteScope", str)
+	}
+	return nil
+}
+
+func (e MessageDeleteScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *MessageDeleteScope) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e MessageDeleteScope) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp NotificationChannel = "IN_APP"
+	NotificationChannelPush  NotificationChannel = "PUSH"
+	NotificationChannelSms   NotificationChannel = "SMS"
+)
+
+var AllNotificationChannel = []NotificationChannel{
+	NotificationChannelInApp,
+	NotificationChannelPush,
+	NotificationChannelSms,
+}
+
+func (e NotificationChannel) IsValid() bool {
+	switch e {
+	case NotificationChannelInApp, NotificationChannelPush, NotificationChannelSms:
+		return true
+	}
+	return false
+}
+
+func (e NotificationChannel) String() string {
+	return string(e)
+}
+
+func (e *NotificationChannel) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationChannel(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationChannel", str)
+	}
+	return nil
+}
+
+func (e NotificationChannel) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *NotificationChannel) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e NotificationChannel) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type NotificationType string
+
+const (
+	NotificationTypeNewMessage     NotificationType = "NEW_MESSAGE"
+	NotificationTypeNewOffer       NotificationType = "NEW_OFFER"
+	NotificationTypeOfferAccepted  NotificationType = "OFFER_ACCEPTED"
+	NotificationTypeReviewReceived NotificationType = "REVIEW_RECEIVED"
+)
+
+var AllNotificationType = []NotificationType{
+	NotificationTypeNewMessage,
+	NotificationTypeNewOffer,
+	NotificationTypeOfferAccepted,
+	NotificationTypeReviewReceived,
+}
+
+func (e NotificationType) IsValid() bool {
+	switch e {
+	case NotificationTypeNewMessage, NotificationTypeNewOffer, NotificationTypeOfferAccepted, NotificationTypeReviewReceived:
+		return true
+	}
+	return false
+}
+
+func (e NotificationType) String() string {
+	return string(e)
+}
+
+func (e *NotificationType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationType", str)
+	}
+	return nil
+}
+
+func (e NotificationType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *NotificationType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e NotificationType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReportStatus string
+
+const (
+	ReportStatusPending   ReportStatus = "PENDING"
+	ReportStatusReviewed  ReportStatus = "REVIEWED"
+	ReportStatusDismissed ReportStatus = "DISMISSED"
+)
+
+var AllReportStatus = []ReportStatus{
+	ReportStatusPending,
+	ReportStatusReviewed,
+	ReportStatusDismissed,
+}
+
+func (e ReportStatus) IsValid() bool {
+	switch e {
+	case ReportStatusPending, ReportStatusReviewed, ReportStatusDismissed:
+		return true
+	}
+	return false
+}
+
+func (e ReportStatus) String() string {
+	return string(e)
+}
+
+func (e *ReportStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReportStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReportStatus", str)
+	}
+	return nil
+}
+
+func (e ReportStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReportStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReportStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SystemEvent string
+
+const (
+	SystemEventParticipantAdded   SystemEvent = "PARTICIPANT_ADDED"
+	SystemEventParticipantRemoved SystemEvent = "PARTICIPANT_REMOVED"
+	SystemEventParticipantLeft    SystemEvent = "PARTICIPANT_LEFT"
+	SystemEventRequestPhotosAdded SystemEvent = "REQUEST_PHOTOS_ADDED"
+)
+
+var AllSystemEvent = []SystemEvent{
+	SystemEventParticipantAdded,
+	SystemEventParticipantRemoved,
+	SystemEventParticipantLeft,
+	SystemEventRequestPhotosAdded,
+}
+
+func (e SystemEvent) IsValid() bool {
+	switch e {
+	case SystemEventParticipantAdded, SystemEventParticipantRemoved, SystemEventParticipantLeft, SystemEventRequestPhotosAdded:
+		return true
+	}
+	return false
+}
+
+func (e SystemEvent) String() string {
+	return string(e)
+}
+
+func (e *SystemEvent) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SystemEvent(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SystemEvent", str)
+	}
+	return nil
+}
+
+func (e SystemEvent) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SystemEvent) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SystemEvent) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+var AllWebhookDeliveryStatus = []WebhookDeliveryStatus{
+	WebhookDeliveryStatusPending,
+	WebhookDeliveryStatusSucceeded,
+	WebhookDeliveryStatusFailed,
+}
+
+func (e WebhookDeliveryStatus) IsValid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e WebhookDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WebhookDeliveryStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
 }
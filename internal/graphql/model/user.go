@@ -0,0 +1,12 @@
+package model
+
+// User is hand-written so resolvers can mark the viewer's own record.
+type User struct {
+	ID string `json:"id"`
+	// Masked unless this is the signed-in user.
+	Phone   string   `json:"phone"`
+	Profile *Profile `json:"profile,omitempty"`
+	// Viewer is set on the caller's own user where the request carries no
+	// auth context yet, e.g. in the payload of login and register.
+	Viewer bool `json:"-"`
+}
@@ -1,21 +1,23 @@
 package resolvers
 
 import (
+	"strings"
 	"time"
 
 	"github.com/barzurustami/bozor/internal/domain"
 	"github.com/barzurustami/bozor/internal/graphql/model"
+	"github.com/barzurustami/bozor/internal/service"
+	"github.com/google/uuid"
 )
 
-func toModelUser(user *domain.User, profile *domain.Profile) *model.User {
+func toModelUser(user *domain.User) *model.User {
 	if user == nil {
 		return nil
 	}
 
 	return &model.User{
-		ID:      user.ID.String(),
-		Phone:   user.Phone,
-		Profile: toModelProfile(profile),
+		ID:    user.ID.String(),
+		Phone: user.Phone,
 	}
 }
 
@@ -33,23 +35,17 @@ func toModelProfile(profile *domain.Profile) *model.Profile {
 	}
 }
 
-func toModelRequest(req *domain.JobRequest, photos []domain.Photo) *model.JobRequest {
+func toModelRequest(req *domain.JobRequest) *model.JobRequest {
 	if req == nil {
 		return nil
 	}
 
-	photoModels := make([]*model.Photo, 0, len(photos))
-	for _, photo := range photos {
-		photoModels = append(photoModels, toModelPhoto(photo))
-	}
-
 	return &model.JobRequest{
 		ID:          req.ID.String(),
 		Title:       req.Title,
 		Description: req.Description,
 		Address:     stringPtr(req.Address),
 		CreatedAt:   model.Time(req.CreatedAt),
-		Photos:      photoModels,
 	}
 }
 
@@ -79,18 +75,87 @@ func toModelChat(chat domain.Chat) *model.Chat {
 		InitiatorID:   chat.InitiatorID.String(),
 		CreatedAt:     model.Time(chat.CreatedAt),
 		LastMessageAt: lastMessageAt,
+		ArchivedAt:    timePtr(chat.Settings.ArchivedAt),
+		MutedUntil:    timePtr(chat.Settings.MutedUntil),
+		PinnedAt:      timePtr(chat.Settings.PinnedAt),
+	}
+}
+
+func toModelParticipant(participant domain.ChatParticipant) *model.ChatParticipant {
+	role := model.ChatParticipantRoleMember
+	if participant.Role == domain.ParticipantRoleOwner {
+		role = model.ChatParticipantRoleOwner
+	}
+
+	return &model.ChatParticipant{
+		UserID:   participant.UserID.String(),
+		Role:     role,
+		JoinedAt: model.Time(participant.JoinedAt),
 	}
 }
 
 func toModelChatMessage(message domain.ChatMessage) *model.ChatMessage {
 	return &model.ChatMessage{
-		ID:        message.ID.String(),
-		ChatID:    message.ChatID.String(),
-		SenderID:  message.SenderID.String(),
-		Text:      stringPtr(message.Text),
-		Photo:     stringPtr(message.PhotoPath),
-		CreatedAt: model.Time(message.CreatedAt),
-		ReadAt:    timePtr(message.ReadAt),
+		ID:          message.ID.String(),
+		ChatID:      message.ChatID.String(),
+		SenderID:    message.SenderID.String(),
+		Text:        stringPtr(message.Text),
+		Photo:       stringPtr(message.PhotoPath),
+		CreatedAt:   model.Time(message.CreatedAt),
+		ReadAt:      timePtr(message.ReadAt),
+		DeliveredAt: timePtr(message.DeliveredAt),
+		EditedAt:    timePtr(message.EditedAt),
+		DeletedAt:   timePtr(message.DeletedAt),
+		ReplyToID:   uuidPtr(message.ReplyToID),
+		Kind:        toModelMessageKind(message.Kind),
+		Held:        message.ModerationStatus == domain.ModerationStatusHeld,
+		System:      toModelSystemPayload(message.System),
+	}
+}
+
+func toModelMessageKind(kind domain.MessageKind) model.ChatMessageKind {
+	if kind == domain.MessageKindSystem {
+		return model.ChatMessageKindSystem
+	}
+	return model.ChatMessageKindUser
+}
+
+func toModelSystemPayload(payload *domain.SystemPayload) *model.SystemPayload {
+	if payload == nil {
+		return nil
+	}
+	return &model.SystemPayload{
+		Event: model.SystemEvent(strings.ToUpper(string(payload.Event))),
+		Data:  payload.Data,
+	}
+}
+
+func toModelAttachment(attachment domain.MessageAttachment) *model.MessageAttachment {
+	return &model.MessageAttachment{
+		ID:         attachment.ID.String(),
+		Path:       attachment.Path,
+		MimeType:   attachment.MimeType,
+		Size:       int(attachment.SizeBytes),
+		FileName:   attachment.OriginalName,
+		DurationMs: attachment.DurationMs,
+		CreatedAt:  model.Time(attachment.CreatedAt),
+	}
+}
+
+func toModelReaction(reaction domain.MessageReaction) *model.MessageReaction {
+	return &model.MessageReaction{
+		MessageID: reaction.MessageID.String(),
+		UserID:    reaction.UserID.String(),
+		Emoji:     reaction.Emoji,
+		CreatedAt: model.Time(reaction.CreatedAt),
+	}
+}
+
+func toModelReactionEvent(event domain.ReactionEvent) *model.ReactionEvent {
+	return &model.ReactionEvent{
+		ChatID:   event.ChatID.String(),
+		Reaction: toModelReaction(event.Reaction),
+		Added:    event.Added,
 	}
 }
 
@@ -101,6 +166,40 @@ func stringPtr(value string) *string {
 	return &value
 }
 
+func toModelSearchHit(hit domain.MessageSearchHit) *model.MessageSearchHit {
+	return &model.MessageSearchHit{
+		Message: toModelChatMessage(hit.Message),
+		Snippet: hit.Snippet,
+		Cursor:  service.EncodeMessageCursor(hit.Message.Cursor()),
+	}
+}
+
+func toModelReport(report domain.ModerationReport) *model.ModerationReport {
+	status := model.ReportStatusPending
+	switch report.Status {
+	case domain.ReportStatusReviewed:
+		status = model.ReportStatusReviewed
+	case domain.ReportStatusDismissed:
+		status = model.ReportStatusDismissed
+	}
+
+	return &model.ModerationReport{
+		ID:        report.ID.String(),
+		ChatID:    uuidPtr(report.ChatID),
+		MessageID: uuidPtr(report.MessageID),
+		Status:    status,
+		CreatedAt: model.Time(report.CreatedAt),
+	}
+}
+
+func uuidPtr(value *uuid.UUID) *string {
+	if value == nil {
+		return nil
+	}
+	converted := value.String()
+	return &converted
+}
+
 func timePtr(value *time.Time) *model.Time {
 	if value == nil {
 		return nil
@@ -108,3 +207,80 @@ func timePtr(value *time.Time) *model.Time {
 	converted := model.Time(*value)
 	return &converted
 }
+
+func toModelNotification(notification domain.Notification) *model.Notification {
+	return &model.Notification{
+		ID:        notification.ID.String(),
+		Type:      model.NotificationType(strings.ToUpper(string(notification.Type))),
+		ActorID:   uuidPtr(notification.ActorID),
+		RequestID: uuidPtr(notification.RequestID),
+		ChatID:    uuidPtr(notification.ChatID),
+		MessageID: uuidPtr(notification.MessageID),
+		ReadAt:    timePtr(notification.ReadAt),
+		CreatedAt: model.Time(notification.CreatedAt),
+	}
+}
+
+// toModelNotificationSettings lists every channel and type combination, with
+// defaults filled in for those the user never set.
+func toModelNotificationSettings(settings domain.NotificationSettings) *model.NotificationSettings {
+	prefs := make([]*model.NotificationPreference, 0, len(domain.NotificationChannels)*len(domain.NotificationTypes))
+	for _, channel := range domain.NotificationChannels {
+		for _, notificationType := range domain.NotificationTypes {
+			prefs = append(prefs, &model.NotificationPreference{
+				Channel: model.NotificationChannel(strings.ToUpper(string(channel))),
+				Type:    model.NotificationType(strings.ToUpper(string(notificationType))),
+				Enabled: settings.Enabled(channel, notificationType),
+			})
+		}
+	}
+
+	return &model.NotificationSettings{
+		PushPreviews: settings.PushPreviews,
+		Preferences:  prefs,
+		QuietHours: &model.QuietHours{
+			Enabled:  settings.QuietHours.Enabled,
+			Start:    domain.FormatClock(settings.QuietHours.Start),
+			End:      domain.FormatClock(settings.QuietHours.End),
+			TimeZone: settings.QuietHours.TimeZone,
+		},
+	}
+}
+
+func toModelDevice(device *domain.Device) *model.Device {
+	return &model.Device{
+		ID:        device.ID.String(),
+		Platform:  model.DevicePlatform(strings.ToUpper(string(device.Platform))),
+		CreatedAt: model.Time(device.CreatedAt),
+	}
+}
+
+func toModelWebhook(hook *domain.Webhook) *model.Webhook {
+	return &model.Webhook{
+		ID:         hook.ID.String(),
+		URL:        hook.URL,
+		EventTypes: hook.EventTypes,
+		Active:     hook.Active,
+		CreatedAt:  model.Time(hook.CreatedAt),
+		UpdatedAt:  model.Time(hook.UpdatedAt),
+	}
+}
+
+func toModelWebhookDelivery(delivery domain.WebhookDelivery) *model.WebhookDelivery {
+	result := &model.WebhookDelivery{
+		ID:             delivery.ID.String(),
+		EventID:        delivery.EventID.String(),
+		EventType:      delivery.EventType,
+		Payload:        string(delivery.Payload),
+		Status:         model.WebhookDeliveryStatus(strings.ToUpper(string(delivery.Status))),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      model.Time(delivery.CreatedAt),
+		CompletedAt:    timePtr(delivery.CompletedAt),
+	}
+	if delivery.Status == domain.WebhookDeliveryPending {
+		result.NextAttemptAt = timePtr(&delivery.NextAttemptAt)
+	}
+	return result
+}
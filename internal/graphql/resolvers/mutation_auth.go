@@ -2,11 +2,9 @@ package resolvers
 
 import (
 	"context"
-	"errors"
 
 	"github.com/barzurustami/bozor/internal/domain"
 	"github.com/barzurustami/bozor/internal/graphql/model"
-	"github.com/barzurustami/bozor/internal/repository"
 )
 
 func resolveRequestSMSCode(ctx context.Context, r *Resolver, phone string) (bool, error) {
@@ -22,10 +20,7 @@ func resolveRegister(ctx context.Context, r *Resolver, input model.RegisterInput
 		return nil, err
 	}
 
-	return &model.AuthPayload{
-		User:   toModelUser(user, nil),
-		Tokens: toModelTokenPair(tokens),
-	}, nil
+	return authPayload(user, tokens), nil
 }
 
 func resolveLogin(ctx context.Context, r *Resolver, input model.LoginInput) (*model.AuthPayload, error) {
@@ -34,20 +29,7 @@ func resolveLogin(ctx context.Context, r *Resolver, input model.LoginInput) (*mo
 		return nil, err
 	}
 
-	var profile *domain.Profile
-	if r.ProfileRepo != nil {
-		profileDomain, err := r.ProfileRepo.GetByUserID(ctx, user.ID)
-		if err == nil {
-			profile = profileDomain
-		} else if !errors.Is(err, repository.ErrNotFound) {
-			return nil, err
-		}
-	}
-
-	return &model.AuthPayload{
-		User:   toModelUser(user, profile),
-		Tokens: toModelTokenPair(tokens),
-	}, nil
+	return authPayload(user, tokens), nil
 }
 
 func resolveRefreshToken(ctx context.Context, r *Resolver, refreshToken string) (*model.AuthPayload, error) {
@@ -56,18 +38,16 @@ func resolveRefreshToken(ctx context.Context, r *Resolver, refreshToken string)
 		return nil, err
 	}
 
-	var profile *domain.Profile
-	if r.ProfileRepo != nil {
-		profileDomain, err := r.ProfileRepo.GetByUserID(ctx, user.ID)
-		if err == nil {
-			profile = profileDomain
-		} else if !errors.Is(err, repository.ErrNotFound) {
-			return nil, err
-		}
-	}
+	return authPayload(user, tokens), nil
+}
 
-	return &model.AuthPayload{
-		User:   toModelUser(user, profile),
+// authPayload marks the user as the viewer: these mutations run before the
+// client holds a token, yet the user is the caller.
+func authPayload(user *domain.User, tokens domain.TokenPair) *model.AuthPayload {
+	payload := &model.AuthPayload{
+		User:   toModelUser(user),
 		Tokens: toModelTokenPair(tokens),
-	}, nil
+	}
+	payload.User.Viewer = true
+	return payload
 }
@@ -0,0 +1,118 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/barzurustami/bozor/internal/auth"
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/graphql/generated"
+	"github.com/barzurustami/bozor/internal/graphql/model"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/barzurustami/bozor/internal/service"
+	"github.com/google/uuid"
+)
+
+type stubUsers struct {
+	byPhone map[string]*domain.User
+}
+
+func (s *stubUsers) GetByPhone(_ context.Context, phone string) (*domain.User, error) {
+	if user, ok := s.byPhone[phone]; ok {
+		return user, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (s *stubUsers) GetByID(context.Context, uuid.UUID) (*domain.User, error) {
+	return nil, repository.ErrNotFound
+}
+
+func (s *stubUsers) ListByIDs(context.Context, []uuid.UUID) ([]domain.User, error) {
+	return nil, nil
+}
+
+func (s *stubUsers) Create(context.Context, *domain.User) error {
+	return nil
+}
+
+// codeCatcher is an SMS sender that keeps the last verification code.
+type codeCatcher struct {
+	code string
+}
+
+func (c *codeCatcher) Send(_ context.Context, _, message string) error {
+	c.code = message[strings.LastIndex(message, " ")+1:]
+	return nil
+}
+
+func TestLoginReturnsUnmaskedPhone(t *testing.T) {
+	const phone = "+998901234567"
+	user := &domain.User{ID: uuid.New(), Phone: phone}
+	sms := &codeCatcher{}
+	jwt := auth.NewJWTService("access", "refresh", time.Minute, time.Hour)
+	authSvc := service.NewAuthService(&stubUsers{byPhone: map[string]*domain.User{phone: user}}, sms, jwt)
+
+	ctx := context.Background()
+	if err := authSvc.RequestCode(ctx, phone); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: &Resolver{AuthService: authSvc}}))
+	srv.AddTransport(transport.POST{})
+
+	body, err := json.Marshal(map[string]any{
+		"query":     `mutation($input: LoginInput!) { login(input: $input) { user { id phone } } }`,
+		"variables": map[string]any{"input": map[string]any{"phone": phone, "code": sms.code}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	var resp struct {
+		Data struct {
+			Login struct {
+				User struct {
+					ID    string `json:"id"`
+					Phone string `json:"phone"`
+				} `json:"user"`
+			} `json:"login"`
+		} `json:"data"`
+		Errors []map[string]any `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode %s: %v", rec.Body.String(), err)
+	}
+	if len(resp.Errors) > 0 {
+		t.Fatalf("errors: %v", resp.Errors)
+	}
+	if resp.Data.Login.User.ID != user.ID.String() {
+		t.Errorf("user id = %q, want %q", resp.Data.Login.User.ID, user.ID)
+	}
+	if resp.Data.Login.User.Phone != phone {
+		t.Errorf("phone = %q, want unmasked %q", resp.Data.Login.User.Phone, phone)
+	}
+}
+
+func TestUserPhoneMaskedForOthers(t *testing.T) {
+	obj := &model.User{ID: uuid.NewString(), Phone: "+998901234567"}
+
+	got, err := resolveUserPhone(context.Background(), &Resolver{}, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "+998*******67"; got != want {
+		t.Errorf("phone = %q, want %q", got, want)
+	}
+}
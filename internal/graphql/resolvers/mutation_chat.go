@@ -2,22 +2,24 @@ package resolvers
 
 import (
 	"context"
-	"fmt"
+	"time"
 
+	"github.com/barzurustami/bozor/internal/apperr"
 	"github.com/barzurustami/bozor/internal/graphql/model"
 	"github.com/barzurustami/bozor/internal/middleware"
+	"github.com/barzurustami/bozor/internal/service"
 	"github.com/google/uuid"
 )
 
 func resolveCreateChat(ctx context.Context, r *Resolver, requestID string) (*model.Chat, error) {
 	userID, ok := middleware.UserIDFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, apperr.ErrUnauthenticated
 	}
 
 	parsedID, err := uuid.Parse(requestID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid request id")
+		return nil, apperr.Validation("invalid request id")
 	}
 
 	chat, err := r.ChatService.CreateChat(ctx, parsedID, userID)
@@ -28,15 +30,80 @@ func resolveCreateChat(ctx context.Context, r *Resolver, requestID string) (*mod
 	return toModelChat(*chat), nil
 }
 
+func resolveAddParticipant(ctx context.Context, r *Resolver, chatID, participantID string) (*model.ChatParticipant, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedChatID, err := uuid.Parse(chatID)
+	if err != nil {
+		return nil, apperr.Validation("invalid chat id")
+	}
+
+	parsedUserID, err := uuid.Parse(participantID)
+	if err != nil {
+		return nil, apperr.Validation("invalid user id")
+	}
+
+	participant, err := r.ChatService.AddParticipant(ctx, parsedChatID, userID, parsedUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelParticipant(*participant), nil
+}
+
+func resolveRemoveParticipant(ctx context.Context, r *Resolver, chatID, participantID string) (bool, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return false, apperr.ErrUnauthenticated
+	}
+
+	parsedChatID, err := uuid.Parse(chatID)
+	if err != nil {
+		return false, apperr.Validation("invalid chat id")
+	}
+
+	parsedUserID, err := uuid.Parse(participantID)
+	if err != nil {
+		return false, apperr.Validation("invalid user id")
+	}
+
+	if err := r.ChatService.RemoveParticipant(ctx, parsedChatID, userID, parsedUserID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resolveLeaveChat(ctx context.Context, r *Resolver, chatID string) (bool, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return false, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(chatID)
+	if err != nil {
+		return false, apperr.Validation("invalid chat id")
+	}
+
+	if err := r.ChatService.LeaveChat(ctx, parsedID, userID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func resolveSendMessage(ctx context.Context, r *Resolver, input model.SendMessageInput) (*model.ChatMessage, error) {
 	userID, ok := middleware.UserIDFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, apperr.ErrUnauthenticated
 	}
 
 	chatID, err := uuid.Parse(input.ChatID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid chat id")
+		return nil, apperr.Validation("invalid chat id")
 	}
 
 	text := ""
@@ -44,7 +111,30 @@ func resolveSendMessage(ctx context.Context, r *Resolver, input model.SendMessag
 		text = *input.Text
 	}
 
-	message, err := r.ChatService.SendMessage(ctx, chatID, userID, text, input.File)
+	var replyToID *uuid.UUID
+	if input.ReplyToID != nil {
+		parsedReplyID, err := uuid.Parse(*input.ReplyToID)
+		if err != nil {
+			return nil, apperr.Validation("invalid reply message id")
+		}
+		replyToID = &parsedReplyID
+	}
+
+	uploads := make([]service.AttachmentUpload, 0, len(input.Attachments)+1)
+	if input.File != nil {
+		uploads = append(uploads, service.AttachmentUpload{File: *input.File})
+	}
+	for _, attachment := range input.Attachments {
+		if attachment == nil {
+			continue
+		}
+		uploads = append(uploads, service.AttachmentUpload{
+			File:       attachment.File,
+			DurationMs: attachment.DurationMs,
+		})
+	}
+
+	message, err := r.ChatService.SendMessage(ctx, chatID, userID, text, uploads, replyToID)
 	if err != nil {
 		return nil, err
 	}
@@ -55,12 +145,12 @@ func resolveSendMessage(ctx context.Context, r *Resolver, input model.SendMessag
 func resolveMarkChatRead(ctx context.Context, r *Resolver, chatID string) ([]*model.ChatMessage, error) {
 	userID, ok := middleware.UserIDFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, apperr.ErrUnauthenticated
 	}
 
 	parsedID, err := uuid.Parse(chatID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid chat id")
+		return nil, apperr.Validation("invalid chat id")
 	}
 
 	messages, err := r.ChatService.MarkChatRead(ctx, parsedID, userID)
@@ -79,12 +169,12 @@ func resolveMarkChatRead(ctx context.Context, r *Resolver, chatID string) ([]*mo
 func resolveMarkMessageRead(ctx context.Context, r *Resolver, messageID string) (*model.ChatMessage, error) {
 	userID, ok := middleware.UserIDFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, apperr.ErrUnauthenticated
 	}
 
 	parsedID, err := uuid.Parse(messageID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid message id")
+		return nil, apperr.Validation("invalid message id")
 	}
 
 	message, err := r.ChatService.MarkMessageRead(ctx, parsedID, userID)
@@ -94,3 +184,169 @@ func resolveMarkMessageRead(ctx context.Context, r *Resolver, messageID string)
 
 	return toModelChatMessage(*message), nil
 }
+
+func resolveMarkDelivered(ctx context.Context, r *Resolver, messageIDs []string) ([]*model.ChatMessage, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedIDs := make([]uuid.UUID, 0, len(messageIDs))
+	for _, messageID := range messageIDs {
+		parsedID, err := uuid.Parse(messageID)
+		if err != nil {
+			return nil, apperr.Validation("invalid message id")
+		}
+		parsedIDs = append(parsedIDs, parsedID)
+	}
+
+	messages, err := r.ChatService.MarkDelivered(ctx, parsedIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.ChatMessage, 0, len(messages))
+	for _, message := range messages {
+		result = append(result, toModelChatMessage(message))
+	}
+
+	return result, nil
+}
+
+func resolveEditMessage(ctx context.Context, r *Resolver, messageID, text string) (*model.ChatMessage, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(messageID)
+	if err != nil {
+		return nil, apperr.Validation("invalid message id")
+	}
+
+	message, err := r.ChatService.EditMessage(ctx, parsedID, userID, text)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelChatMessage(*message), nil
+}
+
+func resolveDeleteMessage(ctx context.Context, r *Resolver, messageID string, scope model.MessageDeleteScope) (bool, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return false, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(messageID)
+	if err != nil {
+		return false, apperr.Validation("invalid message id")
+	}
+
+	forEveryone := scope == model.MessageDeleteScopeEveryone
+	if err := r.ChatService.DeleteMessage(ctx, parsedID, userID, forEveryone); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resolveAddReaction(ctx context.Context, r *Resolver, messageID, emoji string) (*model.MessageReaction, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(messageID)
+	if err != nil {
+		return nil, apperr.Validation("invalid message id")
+	}
+
+	reaction, err := r.ChatService.AddReaction(ctx, parsedID, userID, emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelReaction(*reaction), nil
+}
+
+func resolveRemoveReaction(ctx context.Context, r *Resolver, messageID, emoji string) (bool, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return false, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(messageID)
+	if err != nil {
+		return false, apperr.Validation("invalid message id")
+	}
+
+	if err := r.ChatService.RemoveReaction(ctx, parsedID, userID, emoji); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resolveArchiveChat(ctx context.Context, r *Resolver, chatID string, archived bool) (*model.Chat, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(chatID)
+	if err != nil {
+		return nil, apperr.Validation("invalid chat id")
+	}
+
+	chat, err := r.ChatService.ArchiveChat(ctx, parsedID, userID, archived)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelChat(*chat), nil
+}
+
+func resolveMuteChat(ctx context.Context, r *Resolver, chatID string, until *model.Time) (*model.Chat, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(chatID)
+	if err != nil {
+		return nil, apperr.Validation("invalid chat id")
+	}
+
+	var mutedUntil *time.Time
+	if until != nil {
+		value := time.Time(*until)
+		mutedUntil = &value
+	}
+
+	chat, err := r.ChatService.MuteChat(ctx, parsedID, userID, mutedUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelChat(*chat), nil
+}
+
+func resolvePinChat(ctx context.Context, r *Resolver, chatID string, pinned bool) (*model.Chat, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(chatID)
+	if err != nil {
+		return nil, apperr.Validation("invalid chat id")
+	}
+
+	chat, err := r.ChatService.PinChat(ctx, parsedID, userID, pinned)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelChat(*chat), nil
+}
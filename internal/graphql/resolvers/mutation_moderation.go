@@ -0,0 +1,118 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/graphql/model"
+	"github.com/barzurustami/bozor/internal/middleware"
+	"github.com/google/uuid"
+)
+
+func resolveBlockUser(ctx context.Context, r *Resolver, blockedID string) (bool, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return false, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(blockedID)
+	if err != nil {
+		return false, apperr.Validation("invalid user id")
+	}
+
+	if err := r.ModerationService.BlockUser(ctx, userID, parsedID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func resolveUnblockUser(ctx context.Context, r *Resolver, blockedID string) (bool, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return false, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(blockedID)
+	if err != nil {
+		return false, apperr.Validation("invalid user id")
+	}
+
+	if err := r.ModerationService.UnblockUser(ctx, userID, parsedID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func resolveReportChat(ctx context.Context, r *Resolver, chatID, reason string) (*model.ModerationReport, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(chatID)
+	if err != nil {
+		return nil, apperr.Validation("invalid chat id")
+	}
+
+	report, err := r.ModerationService.ReportChat(ctx, parsedID, userID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelReport(*report), nil
+}
+
+func resolveReportMessage(ctx context.Context, r *Resolver, messageID, reason string) (*model.ModerationReport, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(messageID)
+	if err != nil {
+		return nil, apperr.Validation("invalid message id")
+	}
+
+	report, err := r.ModerationService.ReportMessage(ctx, parsedID, userID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelReport(*report), nil
+}
+
+func resolveReleaseMessage(ctx context.Context, r *Resolver, messageID string) (*model.ChatMessage, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(messageID)
+	if err != nil {
+		return nil, apperr.Validation("invalid message id")
+	}
+
+	message, err := r.ModerationService.ReleaseMessage(ctx, userID, parsedID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelChatMessage(*message), nil
+}
+
+func resolveRejectMessage(ctx context.Context, r *Resolver, messageID string) (bool, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return false, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(messageID)
+	if err != nil {
+		return false, apperr.Validation("invalid message id")
+	}
+
+	if err := r.ModerationService.RejectMessage(ctx, userID, parsedID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,89 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/graphql/model"
+	"github.com/barzurustami/bozor/internal/middleware"
+	"github.com/barzurustami/bozor/internal/service"
+	"github.com/google/uuid"
+)
+
+func resolveMarkNotificationsRead(ctx context.Context, r *Resolver, ids []string) (int, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return 0, apperr.ErrUnauthenticated
+	}
+
+	var parsedIDs []uuid.UUID
+	if ids != nil {
+		parsedIDs = make([]uuid.UUID, 0, len(ids))
+		for _, id := range ids {
+			parsedID, err := uuid.Parse(id)
+			if err != nil {
+				return 0, apperr.Validation("invalid notification id")
+			}
+			parsedIDs = append(parsedIDs, parsedID)
+		}
+	}
+
+	return r.NotificationService.MarkRead(ctx, userID, parsedIDs)
+}
+
+func resolveRegisterDevice(ctx context.Context, r *Resolver, platform model.DevicePlatform, token string) (*model.Device, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	device, err := r.PushService.RegisterDevice(ctx, userID, domain.DevicePlatform(strings.ToLower(string(platform))), token)
+	if err != nil {
+		return nil, err
+	}
+	return toModelDevice(device), nil
+}
+
+func resolveUnregisterDevice(ctx context.Context, r *Resolver, token string) (bool, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return false, apperr.ErrUnauthenticated
+	}
+
+	if err := r.PushService.UnregisterDevice(ctx, userID, token); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func resolveUpdateNotificationSettings(ctx context.Context, r *Resolver, input model.NotificationSettingsInput) (*model.NotificationSettings, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	update := service.NotificationSettingsUpdate{PushPreviews: input.PushPreviews}
+	for _, pref := range input.Preferences {
+		update.Preferences = append(update.Preferences, domain.NotificationPreference{
+			Channel: domain.NotificationChannel(strings.ToLower(string(pref.Channel))),
+			Type:    domain.NotificationType(strings.ToLower(string(pref.Type))),
+			Enabled: pref.Enabled,
+		})
+	}
+	if input.QuietHours != nil {
+		update.QuietHours = &service.QuietHoursUpdate{
+			Enabled:  input.QuietHours.Enabled,
+			Start:    input.QuietHours.Start,
+			End:      input.QuietHours.End,
+			TimeZone: input.QuietHours.TimeZone,
+		}
+	}
+
+	settings, err := r.NotificationService.UpdateSettings(ctx, userID, update)
+	if err != nil {
+		return nil, err
+	}
+	return toModelNotificationSettings(settings), nil
+}
@@ -2,8 +2,8 @@ package resolvers
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/barzurustami/bozor/internal/apperr"
 	"github.com/barzurustami/bozor/internal/graphql/model"
 	"github.com/barzurustami/bozor/internal/middleware"
 )
@@ -11,7 +11,7 @@ import (
 func resolveCreateRequest(ctx context.Context, r *Resolver, input model.CreateRequestInput) (*model.JobRequest, error) {
 	userID, ok := middleware.UserIDFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, apperr.ErrUnauthenticated
 	}
 
 	address := ""
@@ -24,5 +24,5 @@ func resolveCreateRequest(ctx context.Context, r *Resolver, input model.CreateRe
 		return nil, err
 	}
 
-	return toModelRequest(request, nil), nil
+	return toModelRequest(request), nil
 }
@@ -2,22 +2,23 @@ package resolvers
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/99designs/gqlgen/graphql"
+	"github.com/barzurustami/bozor/internal/apperr"
 	"github.com/barzurustami/bozor/internal/graphql/model"
 	"github.com/barzurustami/bozor/internal/middleware"
 	"github.com/google/uuid"
 )
 
 func resolveUploadPhotos(ctx context.Context, r *Resolver, input model.UploadPhotosInput) ([]*model.Photo, error) {
-	if _, ok := middleware.UserIDFromContext(ctx); !ok {
-		return nil, fmt.Errorf("unauthorized")
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
 	}
 
 	requestID, err := uuid.Parse(input.RequestID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid request id")
+		return nil, apperr.Validation("invalid request id")
 	}
 
 	uploads := make([]graphql.Upload, 0, len(input.Files))
@@ -28,7 +29,7 @@ func resolveUploadPhotos(ctx context.Context, r *Resolver, input model.UploadPho
 		uploads = append(uploads, *file)
 	}
 
-	photos, err := r.PhotoService.Upload(ctx, requestID, uploads)
+	photos, err := r.PhotoService.Upload(ctx, requestID, userID, uploads)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,85 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/graphql/model"
+	"github.com/barzurustami/bozor/internal/middleware"
+	"github.com/barzurustami/bozor/internal/service"
+	"github.com/google/uuid"
+)
+
+func resolveCreateWebhook(ctx context.Context, r *Resolver, input model.CreateWebhookInput) (*model.WebhookWithSecret, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	hook, err := r.WebhookService.Create(ctx, userID, service.WebhookInput{
+		URL:        &input.URL,
+		EventTypes: input.EventTypes,
+		Active:     input.Active,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.WebhookWithSecret{Webhook: toModelWebhook(hook), Secret: hook.Secret}, nil
+}
+
+func resolveUpdateWebhook(ctx context.Context, r *Resolver, id string, input model.UpdateWebhookInput) (*model.Webhook, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperr.Validation("invalid webhook id")
+	}
+
+	hook, err := r.WebhookService.Update(ctx, userID, parsedID, service.WebhookInput{
+		URL:        input.URL,
+		EventTypes: input.EventTypes,
+		Active:     input.Active,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toModelWebhook(hook), nil
+}
+
+func resolveRotateWebhookSecret(ctx context.Context, r *Resolver, id string) (*model.WebhookWithSecret, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperr.Validation("invalid webhook id")
+	}
+
+	hook, err := r.WebhookService.RotateSecret(ctx, userID, parsedID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.WebhookWithSecret{Webhook: toModelWebhook(hook), Secret: hook.Secret}, nil
+}
+
+func resolveDeleteWebhook(ctx context.Context, r *Resolver, id string) (bool, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return false, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return false, apperr.Validation("invalid webhook id")
+	}
+
+	if err := r.WebhookService.Delete(ctx, userID, parsedID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
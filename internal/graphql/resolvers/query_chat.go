@@ -2,20 +2,32 @@ package resolvers
 
 import (
 	"context"
-	"fmt"
+	"errors"
 
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/domain"
 	"github.com/barzurustami/bozor/internal/graphql/model"
 	"github.com/barzurustami/bozor/internal/middleware"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/barzurustami/bozor/internal/service"
 	"github.com/google/uuid"
 )
 
-func resolveChats(ctx context.Context, r *Resolver) ([]*model.Chat, error) {
+func resolveChats(ctx context.Context, r *Resolver, filter *model.ChatFilter) ([]*model.Chat, error) {
 	userID, ok := middleware.UserIDFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, apperr.ErrUnauthenticated
 	}
 
-	chats, err := r.ChatService.ListChats(ctx, userID)
+	// Without a filter the main list hides archived chats, matching the
+	// ChatFilter default.
+	archived := false
+	chatFilter := repository.ChatFilter{Archived: &archived}
+	if filter != nil {
+		chatFilter.Archived = filter.Archived
+	}
+
+	chats, err := r.ChatService.ListChats(ctx, userID, chatFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -28,15 +40,15 @@ func resolveChats(ctx context.Context, r *Resolver) ([]*model.Chat, error) {
 	return result, nil
 }
 
-func resolveChatMessages(ctx context.Context, r *Resolver, chatID string, limit, offset *int) ([]*model.ChatMessage, error) {
+func resolveChatMessages(ctx context.Context, r *Resolver, chatID string, limit, offset *int, around *string) ([]*model.ChatMessage, error) {
 	userID, ok := middleware.UserIDFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, apperr.ErrUnauthenticated
 	}
 
 	parsedID, err := uuid.Parse(chatID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid chat id")
+		return nil, apperr.Validation("invalid chat id")
 	}
 
 	limitVal := 50
@@ -57,9 +69,21 @@ func resolveChatMessages(ctx context.Context, r *Resolver, chatID string, limit,
 		offsetVal = 0
 	}
 
-	messages, err := r.ChatService.ListMessages(ctx, parsedID, userID, int32(limitVal), int32(offsetVal))
-	if err != nil {
-		return nil, err
+	var messages []domain.ChatMessage
+	if around != nil {
+		cursor, err := service.DecodeMessageCursor(*around)
+		if err != nil {
+			return nil, err
+		}
+		messages, err = r.ChatService.ListMessagesAround(ctx, parsedID, userID, cursor, int32(limitVal))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		messages, err = r.ChatService.ListMessages(ctx, parsedID, userID, int32(limitVal), int32(offsetVal))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	result := make([]*model.ChatMessage, 0, len(messages))
@@ -69,3 +93,200 @@ func resolveChatMessages(ctx context.Context, r *Resolver, chatID string, limit,
 
 	return result, nil
 }
+
+func resolveSearchMessages(ctx context.Context, r *Resolver, query string, chatID *string, first *int, after *string) (*model.MessageSearchResult, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	var parsedChatID *uuid.UUID
+	if chatID != nil {
+		parsedID, err := uuid.Parse(*chatID)
+		if err != nil {
+			return nil, apperr.Validation("invalid chat id")
+		}
+		parsedChatID = &parsedID
+	}
+
+	var afterCursor *domain.MessageCursor
+	if after != nil {
+		cursor, err := service.DecodeMessageCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+		afterCursor = &cursor
+	}
+
+	limitVal := 20
+	if first != nil {
+		limitVal = *first
+	}
+	if limitVal <= 0 {
+		limitVal = 20
+	}
+	if limitVal > 50 {
+		limitVal = 50
+	}
+
+	hits, hasMore, err := r.ChatService.SearchMessages(ctx, userID, query, parsedChatID, afterCursor, int32(limitVal))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.MessageSearchResult{
+		Hits:    make([]*model.MessageSearchHit, 0, len(hits)),
+		HasMore: hasMore,
+	}
+	for _, hit := range hits {
+		result.Hits = append(result.Hits, toModelSearchHit(hit))
+	}
+	if len(result.Hits) > 0 {
+		endCursor := result.Hits[len(result.Hits)-1].Cursor
+		result.EndCursor = &endCursor
+	}
+
+	return result, nil
+}
+
+func resolveChatUnreadCount(ctx context.Context, r *Resolver, obj *model.Chat) (int, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return 0, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return 0, apperr.Validation("invalid chat id")
+	}
+
+	return r.ChatService.UnreadCount(ctx, parsedID, userID)
+}
+
+func resolveChatParticipants(ctx context.Context, r *Resolver, obj *model.Chat) ([]*model.ChatParticipant, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, apperr.Validation("invalid chat id")
+	}
+
+	participants, err := r.ChatService.ListParticipants(ctx, parsedID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.ChatParticipant, 0, len(participants))
+	for _, participant := range participants {
+		result = append(result, toModelParticipant(participant))
+	}
+
+	return result, nil
+}
+
+func resolveChatMessageReplyTo(ctx context.Context, r *Resolver, obj *model.ChatMessage) (*model.ChatMessage, error) {
+	if obj.ReplyToID == nil {
+		return nil, nil
+	}
+
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(*obj.ReplyToID)
+	if err != nil {
+		return nil, apperr.Validation("invalid reply message id")
+	}
+
+	message, err := r.ChatService.GetMessage(ctx, parsedID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toModelChatMessage(*message), nil
+}
+
+func resolveChatMessageReactions(ctx context.Context, r *Resolver, obj *model.ChatMessage) ([]*model.MessageReaction, error) {
+	parsedID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, apperr.Validation("invalid message id")
+	}
+
+	reactions, err := r.ChatService.ListReactions(ctx, parsedID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.MessageReaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		result = append(result, toModelReaction(reaction))
+	}
+
+	return result, nil
+}
+
+func resolveChatMessageAttachments(ctx context.Context, r *Resolver, obj *model.ChatMessage) ([]*model.MessageAttachment, error) {
+	parsedID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, apperr.Validation("invalid message id")
+	}
+
+	attachments, err := r.ChatService.ListAttachments(ctx, parsedID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.MessageAttachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		result = append(result, toModelAttachment(attachment))
+	}
+
+	return result, nil
+}
+
+func resolveChatRequest(ctx context.Context, r *Resolver, obj *model.Chat) (*model.JobRequest, error) {
+	parsedID, err := uuid.Parse(obj.RequestID)
+	if err != nil {
+		return nil, apperr.Validation("invalid request id")
+	}
+
+	request, err := r.loaders(ctx).Requests.Load(ctx, parsedID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, repository.ErrNotFound
+	}
+	return toModelRequest(request), nil
+}
+
+func resolveChatCreator(ctx context.Context, r *Resolver, obj *model.Chat) (*model.User, error) {
+	return loadUser(ctx, r, obj.CreatorID)
+}
+
+func resolveChatMessageSender(ctx context.Context, r *Resolver, obj *model.ChatMessage) (*model.User, error) {
+	return loadUser(ctx, r, obj.SenderID)
+}
+
+func loadUser(ctx context.Context, r *Resolver, id string) (*model.User, error) {
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperr.Validation("invalid user id")
+	}
+
+	user, err := r.loaders(ctx).Users.Load(ctx, parsedID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, repository.ErrNotFound
+	}
+	return toModelUser(user), nil
+}
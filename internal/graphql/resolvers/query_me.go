@@ -2,12 +2,9 @@ package resolvers
 
 import (
 	"context"
-	"errors"
 
-	"github.com/barzurustami/bozor/internal/domain"
 	"github.com/barzurustami/bozor/internal/graphql/model"
 	"github.com/barzurustami/bozor/internal/middleware"
-	"github.com/barzurustami/bozor/internal/repository"
 )
 
 func resolveMe(ctx context.Context, r *Resolver) (*model.User, error) {
@@ -21,17 +18,5 @@ func resolveMe(ctx context.Context, r *Resolver) (*model.User, error) {
 		return nil, err
 	}
 
-	var profile *domain.Profile
-	if r.ProfileRepo != nil {
-		profile, err = r.ProfileRepo.GetByUserID(ctx, userID)
-		if err != nil {
-			if errors.Is(err, repository.ErrNotFound) {
-				profile = nil
-			} else {
-				return nil, err
-			}
-		}
-	}
-
-	return toModelUser(user, profile), nil
+	return toModelUser(user), nil
 }
@@ -0,0 +1,35 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/graphql/model"
+	"github.com/barzurustami/bozor/internal/middleware"
+)
+
+func resolveHeldMessages(ctx context.Context, r *Resolver, limit *int) ([]*model.HeldMessage, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	limitVal := 50
+	if limit != nil {
+		limitVal = *limit
+	}
+
+	messages, err := r.ModerationService.HeldMessages(ctx, userID, int32(limitVal))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.HeldMessage, 0, len(messages))
+	for _, message := range messages {
+		result = append(result, &model.HeldMessage{
+			Message: toModelChatMessage(message),
+			Reasons: message.ModerationReasons,
+		})
+	}
+	return result, nil
+}
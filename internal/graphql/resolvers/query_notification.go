@@ -0,0 +1,110 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/graphql/model"
+	"github.com/barzurustami/bozor/internal/middleware"
+	"github.com/barzurustami/bozor/internal/service"
+	"github.com/google/uuid"
+)
+
+func resolveNotifications(ctx context.Context, r *Resolver, first *int, after *string) (*model.NotificationPage, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	var afterCursor *domain.MessageCursor
+	if after != nil {
+		cursor, err := service.DecodeMessageCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+		afterCursor = &cursor
+	}
+
+	limitVal := 20
+	if first != nil {
+		limitVal = *first
+	}
+	if limitVal <= 0 {
+		limitVal = 20
+	}
+	if limitVal > 50 {
+		limitVal = 50
+	}
+
+	notifications, hasMore, err := r.NotificationService.List(ctx, userID, afterCursor, int32(limitVal))
+	if err != nil {
+		return nil, err
+	}
+	unread, err := r.NotificationService.UnreadCount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.NotificationPage{
+		Notifications: make([]*model.Notification, 0, len(notifications)),
+		HasMore:       hasMore,
+		UnreadCount:   unread,
+	}
+	for _, notification := range notifications {
+		page.Notifications = append(page.Notifications, toModelNotification(notification))
+	}
+	if len(notifications) > 0 {
+		endCursor := service.EncodeMessageCursor(notifications[len(notifications)-1].Cursor())
+		page.EndCursor = &endCursor
+	}
+
+	return page, nil
+}
+
+func resolveUnreadNotificationCount(ctx context.Context, r *Resolver) (int, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return 0, apperr.ErrUnauthenticated
+	}
+	return r.NotificationService.UnreadCount(ctx, userID)
+}
+
+func resolveNotificationActor(ctx context.Context, r *Resolver, obj *model.Notification) (*model.User, error) {
+	if obj.ActorID == nil {
+		return nil, nil
+	}
+	return loadUser(ctx, r, *obj.ActorID)
+}
+
+// resolveNotificationRequest returns nil rather than an error when the
+// request is gone, so one stale notification does not fail the whole page.
+func resolveNotificationRequest(ctx context.Context, r *Resolver, obj *model.Notification) (*model.JobRequest, error) {
+	if obj.RequestID == nil {
+		return nil, nil
+	}
+
+	parsedID, err := uuid.Parse(*obj.RequestID)
+	if err != nil {
+		return nil, apperr.Validation("invalid request id")
+	}
+
+	request, err := r.loaders(ctx).Requests.Load(ctx, parsedID)
+	if err != nil {
+		return nil, err
+	}
+	return toModelRequest(request), nil
+}
+
+func resolveNotificationSettings(ctx context.Context, r *Resolver) (*model.NotificationSettings, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	settings, err := r.NotificationService.Settings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toModelNotificationSettings(settings), nil
+}
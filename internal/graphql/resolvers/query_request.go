@@ -0,0 +1,27 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/graphql/model"
+	"github.com/google/uuid"
+)
+
+func resolveJobRequestPhotos(ctx context.Context, r *Resolver, obj *model.JobRequest) ([]*model.Photo, error) {
+	parsedID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, apperr.Validation("invalid request id")
+	}
+
+	photos, err := r.loaders(ctx).Photos.Load(ctx, parsedID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Photo, 0, len(photos))
+	for _, photo := range photos {
+		result = append(result, toModelPhoto(photo))
+	}
+	return result, nil
+}
@@ -0,0 +1,44 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/graphql/model"
+	"github.com/barzurustami/bozor/internal/middleware"
+	"github.com/google/uuid"
+)
+
+func resolveUserPhone(ctx context.Context, r *Resolver, obj *model.User) (string, error) {
+	if obj.Viewer {
+		return obj.Phone, nil
+	}
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if ok && userID.String() == obj.ID {
+		return obj.Phone, nil
+	}
+	return maskPhone(obj.Phone), nil
+}
+
+func resolveUserProfile(ctx context.Context, r *Resolver, obj *model.User) (*model.Profile, error) {
+	parsedID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, apperr.Validation("invalid user id")
+	}
+
+	profile, err := r.loaders(ctx).Profiles.Load(ctx, parsedID)
+	if err != nil {
+		return nil, err
+	}
+	return toModelProfile(profile), nil
+}
+
+// maskPhone keeps the country prefix and the last two digits so other users
+// can tell numbers apart without learning them.
+func maskPhone(phone string) string {
+	if len(phone) <= 6 {
+		return strings.Repeat("*", len(phone))
+	}
+	return phone[:4] + strings.Repeat("*", len(phone)-6) + phone[len(phone)-2:]
+}
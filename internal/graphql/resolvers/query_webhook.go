@@ -0,0 +1,61 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/graphql/model"
+	"github.com/barzurustami/bozor/internal/middleware"
+	"github.com/barzurustami/bozor/internal/webhook"
+	"github.com/google/uuid"
+)
+
+func resolveWebhooks(ctx context.Context, r *Resolver) ([]*model.Webhook, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	hooks, err := r.WebhookService.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Webhook, 0, len(hooks))
+	for i := range hooks {
+		result = append(result, toModelWebhook(&hooks[i]))
+	}
+	return result, nil
+}
+
+func resolveWebhookDeliveries(ctx context.Context, r *Resolver, webhookID string, limit *int) ([]*model.WebhookDelivery, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(webhookID)
+	if err != nil {
+		return nil, apperr.Validation("invalid webhook id")
+	}
+
+	limitVal := 50
+	if limit != nil {
+		limitVal = *limit
+	}
+
+	deliveries, err := r.WebhookService.Deliveries(ctx, userID, parsedID, int32(limitVal))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, toModelWebhookDelivery(delivery))
+	}
+	return result, nil
+}
+
+func resolveWebhookEventTypes(ctx context.Context, r *Resolver) ([]string, error) {
+	return webhook.EventTypes, nil
+}
@@ -1,16 +1,32 @@
 package resolvers
 
 import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/dataloader"
 	"github.com/barzurustami/bozor/internal/repository"
 	"github.com/barzurustami/bozor/internal/service"
 )
 
 type Resolver struct {
-	AuthService    *service.AuthService
-	ProfileService *service.ProfileService
-	RequestService *service.RequestService
-	PhotoService   *service.PhotoService
-	ChatService    *service.ChatService
-	UserRepo       repository.UserRepository
-	ProfileRepo    repository.ProfileRepository
+	AuthService         *service.AuthService
+	ProfileService      *service.ProfileService
+	RequestService      *service.RequestService
+	PhotoService        *service.PhotoService
+	ChatService         *service.ChatService
+	ModerationService   *service.ModerationService
+	NotificationService *service.NotificationService
+	PushService         *service.PushService
+	WebhookService      *service.WebhookService
+	UserRepo            repository.UserRepository
+	Loaders             dataloader.Sources
+}
+
+// loaders returns the loaders attached to the current response, or fresh
+// ones when resolving outside the GraphQL handler.
+func (r *Resolver) loaders(ctx context.Context) *dataloader.Loaders {
+	if loaders, ok := dataloader.FromContext(ctx); ok {
+		return loaders
+	}
+	return dataloader.NewLoaders(ctx, r.Loaders)
 }
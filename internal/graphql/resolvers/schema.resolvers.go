@@ -7,6 +7,50 @@ import (
 	"github.com/barzurustami/bozor/internal/graphql/model"
 )
 
+func (r *chatResolver) UnreadCount(ctx context.Context, obj *model.Chat) (int, error) {
+	return resolveChatUnreadCount(ctx, r.Resolver, obj)
+}
+
+func (r *chatResolver) Participants(ctx context.Context, obj *model.Chat) ([]*model.ChatParticipant, error) {
+	return resolveChatParticipants(ctx, r.Resolver, obj)
+}
+
+func (r *chatResolver) Request(ctx context.Context, obj *model.Chat) (*model.JobRequest, error) {
+	return resolveChatRequest(ctx, r.Resolver, obj)
+}
+
+func (r *chatResolver) Creator(ctx context.Context, obj *model.Chat) (*model.User, error) {
+	return resolveChatCreator(ctx, r.Resolver, obj)
+}
+
+func (r *chatMessageResolver) Sender(ctx context.Context, obj *model.ChatMessage) (*model.User, error) {
+	return resolveChatMessageSender(ctx, r.Resolver, obj)
+}
+
+func (r *jobRequestResolver) Photos(ctx context.Context, obj *model.JobRequest) ([]*model.Photo, error) {
+	return resolveJobRequestPhotos(ctx, r.Resolver, obj)
+}
+
+func (r *userResolver) Phone(ctx context.Context, obj *model.User) (string, error) {
+	return resolveUserPhone(ctx, r.Resolver, obj)
+}
+
+func (r *userResolver) Profile(ctx context.Context, obj *model.User) (*model.Profile, error) {
+	return resolveUserProfile(ctx, r.Resolver, obj)
+}
+
+func (r *chatMessageResolver) ReplyTo(ctx context.Context, obj *model.ChatMessage) (*model.ChatMessage, error) {
+	return resolveChatMessageReplyTo(ctx, r.Resolver, obj)
+}
+
+func (r *chatMessageResolver) Reactions(ctx context.Context, obj *model.ChatMessage) ([]*model.MessageReaction, error) {
+	return resolveChatMessageReactions(ctx, r.Resolver, obj)
+}
+
+func (r *chatMessageResolver) Attachments(ctx context.Context, obj *model.ChatMessage) ([]*model.MessageAttachment, error) {
+	return resolveChatMessageAttachments(ctx, r.Resolver, obj)
+}
+
 func (r *mutationResolver) RequestSMSCode(ctx context.Context, phone string) (bool, error) {
 	return resolveRequestSMSCode(ctx, r.Resolver, phone)
 }
@@ -31,6 +75,30 @@ func (r *mutationResolver) CreateChat(ctx context.Context, requestID string) (*m
 	return resolveCreateChat(ctx, r.Resolver, requestID)
 }
 
+func (r *mutationResolver) AddParticipant(ctx context.Context, chatID string, userID string) (*model.ChatParticipant, error) {
+	return resolveAddParticipant(ctx, r.Resolver, chatID, userID)
+}
+
+func (r *mutationResolver) RemoveParticipant(ctx context.Context, chatID string, userID string) (bool, error) {
+	return resolveRemoveParticipant(ctx, r.Resolver, chatID, userID)
+}
+
+func (r *mutationResolver) LeaveChat(ctx context.Context, chatID string) (bool, error) {
+	return resolveLeaveChat(ctx, r.Resolver, chatID)
+}
+
+func (r *mutationResolver) ArchiveChat(ctx context.Context, chatID string, archived bool) (*model.Chat, error) {
+	return resolveArchiveChat(ctx, r.Resolver, chatID, archived)
+}
+
+func (r *mutationResolver) MuteChat(ctx context.Context, chatID string, until *model.Time) (*model.Chat, error) {
+	return resolveMuteChat(ctx, r.Resolver, chatID, until)
+}
+
+func (r *mutationResolver) PinChat(ctx context.Context, chatID string, pinned bool) (*model.Chat, error) {
+	return resolvePinChat(ctx, r.Resolver, chatID, pinned)
+}
+
 func (r *mutationResolver) SendMessage(ctx context.Context, input model.SendMessageInput) (*model.ChatMessage, error) {
 	return resolveSendMessage(ctx, r.Resolver, input)
 }
@@ -43,6 +111,42 @@ func (r *mutationResolver) MarkMessageRead(ctx context.Context, messageID string
 	return resolveMarkMessageRead(ctx, r.Resolver, messageID)
 }
 
+func (r *mutationResolver) MarkDelivered(ctx context.Context, messageIds []string) ([]*model.ChatMessage, error) {
+	return resolveMarkDelivered(ctx, r.Resolver, messageIds)
+}
+
+func (r *mutationResolver) EditMessage(ctx context.Context, messageID string, text string) (*model.ChatMessage, error) {
+	return resolveEditMessage(ctx, r.Resolver, messageID, text)
+}
+
+func (r *mutationResolver) DeleteMessage(ctx context.Context, messageID string, scope model.MessageDeleteScope) (bool, error) {
+	return resolveDeleteMessage(ctx, r.Resolver, messageID, scope)
+}
+
+func (r *mutationResolver) AddReaction(ctx context.Context, messageID string, emoji string) (*model.MessageReaction, error) {
+	return resolveAddReaction(ctx, r.Resolver, messageID, emoji)
+}
+
+func (r *mutationResolver) RemoveReaction(ctx context.Context, messageID string, emoji string) (bool, error) {
+	return resolveRemoveReaction(ctx, r.Resolver, messageID, emoji)
+}
+
+func (r *mutationResolver) BlockUser(ctx context.Context, userID string) (bool, error) {
+	return resolveBlockUser(ctx, r.Resolver, userID)
+}
+
+func (r *mutationResolver) UnblockUser(ctx context.Context, userID string) (bool, error) {
+	return resolveUnblockUser(ctx, r.Resolver, userID)
+}
+
+func (r *mutationResolver) ReportChat(ctx context.Context, chatID string, reason string) (*model.ModerationReport, error) {
+	return resolveReportChat(ctx, r.Resolver, chatID, reason)
+}
+
+func (r *mutationResolver) ReportMessage(ctx context.Context, messageID string, reason string) (*model.ModerationReport, error) {
+	return resolveReportMessage(ctx, r.Resolver, messageID, reason)
+}
+
 func (r *mutationResolver) UploadPhotos(ctx context.Context, input model.UploadPhotosInput) ([]*model.Photo, error) {
 	return resolveUploadPhotos(ctx, r.Resolver, input)
 }
@@ -55,12 +159,16 @@ func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
 	return resolveMe(ctx, r.Resolver)
 }
 
-func (r *queryResolver) Chats(ctx context.Context) ([]*model.Chat, error) {
-	return resolveChats(ctx, r.Resolver)
+func (r *queryResolver) Chats(ctx context.Context, filter *model.ChatFilter) ([]*model.Chat, error) {
+	return resolveChats(ctx, r.Resolver, filter)
 }
 
-func (r *queryResolver) ChatMessages(ctx context.Context, chatID string, limit *int, offset *int) ([]*model.ChatMessage, error) {
-	return resolveChatMessages(ctx, r.Resolver, chatID, limit, offset)
+func (r *queryResolver) ChatMessages(ctx context.Context, chatID string, limit *int, offset *int, around *string) ([]*model.ChatMessage, error) {
+	return resolveChatMessages(ctx, r.Resolver, chatID, limit, offset, around)
+}
+
+func (r *queryResolver) SearchMessages(ctx context.Context, query string, chatID *string, first *int, after *string) (*model.MessageSearchResult, error) {
+	return resolveSearchMessages(ctx, r.Resolver, query, chatID, first, after)
 }
 
 func (r *subscriptionResolver) ChatMessageAdded(ctx context.Context, chatID string) (<-chan *model.ChatMessage, error) {
@@ -71,14 +179,126 @@ func (r *subscriptionResolver) ChatMessageRead(ctx context.Context, chatID strin
 	return resolveChatMessageRead(ctx, r.Resolver, chatID)
 }
 
+func (r *subscriptionResolver) ChatMessageDelivered(ctx context.Context, chatID string) (<-chan *model.ChatMessage, error) {
+	return resolveChatMessageDelivered(ctx, r.Resolver, chatID)
+}
+
+func (r *subscriptionResolver) ChatMessageUpdated(ctx context.Context, chatID string) (<-chan *model.ChatMessage, error) {
+	return resolveChatMessageUpdated(ctx, r.Resolver, chatID)
+}
+
+func (r *subscriptionResolver) ChatReactionChanged(ctx context.Context, chatID string) (<-chan *model.ReactionEvent, error) {
+	return resolveChatReactionChanged(ctx, r.Resolver, chatID)
+}
+
+func (r *Resolver) Chat() generated.ChatResolver { return &chatResolver{r} }
+
+func (r *Resolver) ChatMessage() generated.ChatMessageResolver { return &chatMessageResolver{r} }
+
+func (r *Resolver) JobRequest() generated.JobRequestResolver { return &jobRequestResolver{r} }
+
+func (r *Resolver) User() generated.UserResolver { return &userResolver{r} }
+
+func (r *mutationResolver) MarkNotificationsRead(ctx context.Context, ids []string) (int, error) {
+	return resolveMarkNotificationsRead(ctx, r.Resolver, ids)
+}
+
+func (r *notificationResolver) Actor(ctx context.Context, obj *model.Notification) (*model.User, error) {
+	return resolveNotificationActor(ctx, r.Resolver, obj)
+}
+
+func (r *notificationResolver) Request(ctx context.Context, obj *model.Notification) (*model.JobRequest, error) {
+	return resolveNotificationRequest(ctx, r.Resolver, obj)
+}
+
+func (r *queryResolver) Notifications(ctx context.Context, first *int, after *string) (*model.NotificationPage, error) {
+	return resolveNotifications(ctx, r.Resolver, first, after)
+}
+
+func (r *queryResolver) UnreadNotificationCount(ctx context.Context) (int, error) {
+	return resolveUnreadNotificationCount(ctx, r.Resolver)
+}
+
+func (r *subscriptionResolver) NotificationAdded(ctx context.Context) (<-chan *model.Notification, error) {
+	return resolveNotificationAdded(ctx, r.Resolver)
+}
+
+func (r *mutationResolver) RegisterDevice(ctx context.Context, platform model.DevicePlatform, token string) (*model.Device, error) {
+	return resolveRegisterDevice(ctx, r.Resolver, platform, token)
+}
+
+func (r *mutationResolver) UnregisterDevice(ctx context.Context, token string) (bool, error) {
+	return resolveUnregisterDevice(ctx, r.Resolver, token)
+}
+
+func (r *mutationResolver) UpdateNotificationSettings(ctx context.Context, input model.NotificationSettingsInput) (*model.NotificationSettings, error) {
+	return resolveUpdateNotificationSettings(ctx, r.Resolver, input)
+}
+
+func (r *queryResolver) NotificationSettings(ctx context.Context) (*model.NotificationSettings, error) {
+	return resolveNotificationSettings(ctx, r.Resolver)
+}
+
+func (r *mutationResolver) CreateWebhook(ctx context.Context, input model.CreateWebhookInput) (*model.WebhookWithSecret, error) {
+	return resolveCreateWebhook(ctx, r.Resolver, input)
+}
+
+func (r *mutationResolver) UpdateWebhook(ctx context.Context, id string, input model.UpdateWebhookInput) (*model.Webhook, error) {
+	return resolveUpdateWebhook(ctx, r.Resolver, id, input)
+}
+
+func (r *mutationResolver) RotateWebhookSecret(ctx context.Context, id string) (*model.WebhookWithSecret, error) {
+	return resolveRotateWebhookSecret(ctx, r.Resolver, id)
+}
+
+func (r *mutationResolver) DeleteWebhook(ctx context.Context, id string) (bool, error) {
+	return resolveDeleteWebhook(ctx, r.Resolver, id)
+}
+
+func (r *queryResolver) Webhooks(ctx context.Context) ([]*model.Webhook, error) {
+	return resolveWebhooks(ctx, r.Resolver)
+}
+
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, webhookID string, limit *int) ([]*model.WebhookDelivery, error) {
+	return resolveWebhookDeliveries(ctx, r.Resolver, webhookID, limit)
+}
+
+func (r *queryResolver) WebhookEventTypes(ctx context.Context) ([]string, error) {
+	return resolveWebhookEventTypes(ctx, r.Resolver)
+}
+
+func (r *mutationResolver) ReleaseMessage(ctx context.Context, messageID string) (*model.ChatMessage, error) {
+	return resolveReleaseMessage(ctx, r.Resolver, messageID)
+}
+
+func (r *mutationResolver) RejectMessage(ctx context.Context, messageID string) (bool, error) {
+	return resolveRejectMessage(ctx, r.Resolver, messageID)
+}
+
+func (r *queryResolver) HeldMessages(ctx context.Context, limit *int) ([]*model.HeldMessage, error) {
+	return resolveHeldMessages(ctx, r.Resolver, limit)
+}
+
+func (r *Resolver) Notification() generated.NotificationResolver { return &notificationResolver{r} }
+
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
 func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
 
+type chatResolver struct{ *Resolver }
+
+type chatMessageResolver struct{ *Resolver }
+
+type jobRequestResolver struct{ *Resolver }
+
+type userResolver struct{ *Resolver }
+
 type mutationResolver struct{ *Resolver }
 
+type notificationResolver struct{ *Resolver }
+
 type queryResolver struct{ *Resolver }
 
 type subscriptionResolver struct{ *Resolver }
@@ -2,8 +2,8 @@ package resolvers
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/barzurustami/bozor/internal/apperr"
 	"github.com/barzurustami/bozor/internal/graphql/model"
 	"github.com/barzurustami/bozor/internal/middleware"
 	"github.com/google/uuid"
@@ -12,12 +12,12 @@ import (
 func resolveChatMessageAdded(ctx context.Context, r *Resolver, chatID string) (<-chan *model.ChatMessage, error) {
 	userID, ok := middleware.UserIDFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, apperr.ErrUnauthenticated
 	}
 
 	parsedID, err := uuid.Parse(chatID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid chat id")
+		return nil, apperr.Validation("invalid chat id")
 	}
 
 	domainCh, err := r.ChatService.SubscribeMessages(ctx, parsedID, userID)
@@ -39,12 +39,12 @@ func resolveChatMessageAdded(ctx context.Context, r *Resolver, chatID string) (<
 func resolveChatMessageRead(ctx context.Context, r *Resolver, chatID string) (<-chan *model.ChatMessage, error) {
 	userID, ok := middleware.UserIDFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, apperr.ErrUnauthenticated
 	}
 
 	parsedID, err := uuid.Parse(chatID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid chat id")
+		return nil, apperr.Validation("invalid chat id")
 	}
 
 	domainCh, err := r.ChatService.SubscribeReads(ctx, parsedID, userID)
@@ -62,3 +62,84 @@ func resolveChatMessageRead(ctx context.Context, r *Resolver, chatID string) (<-
 
 	return out, nil
 }
+
+func resolveChatMessageDelivered(ctx context.Context, r *Resolver, chatID string) (<-chan *model.ChatMessage, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(chatID)
+	if err != nil {
+		return nil, apperr.Validation("invalid chat id")
+	}
+
+	domainCh, err := r.ChatService.SubscribeDeliveries(ctx, parsedID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *model.ChatMessage, 1)
+	go func() {
+		defer close(out)
+		for msg := range domainCh {
+			out <- toModelChatMessage(msg)
+		}
+	}()
+
+	return out, nil
+}
+
+func resolveChatMessageUpdated(ctx context.Context, r *Resolver, chatID string) (<-chan *model.ChatMessage, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(chatID)
+	if err != nil {
+		return nil, apperr.Validation("invalid chat id")
+	}
+
+	domainCh, err := r.ChatService.SubscribeUpdates(ctx, parsedID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *model.ChatMessage, 1)
+	go func() {
+		defer close(out)
+		for msg := range domainCh {
+			out <- toModelChatMessage(msg)
+		}
+	}()
+
+	return out, nil
+}
+
+func resolveChatReactionChanged(ctx context.Context, r *Resolver, chatID string) (<-chan *model.ReactionEvent, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	parsedID, err := uuid.Parse(chatID)
+	if err != nil {
+		return nil, apperr.Validation("invalid chat id")
+	}
+
+	domainCh, err := r.ChatService.SubscribeReactions(ctx, parsedID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *model.ReactionEvent, 1)
+	go func() {
+		defer close(out)
+		for event := range domainCh {
+			out <- toModelReactionEvent(event)
+		}
+	}()
+
+	return out, nil
+}
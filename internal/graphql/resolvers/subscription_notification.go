@@ -0,0 +1,28 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/graphql/model"
+	"github.com/barzurustami/bozor/internal/middleware"
+)
+
+func resolveNotificationAdded(ctx context.Context, r *Resolver) (<-chan *model.Notification, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	domainCh := r.NotificationService.Subscribe(ctx, userID)
+
+	out := make(chan *model.Notification, 1)
+	go func() {
+		defer close(out)
+		for notification := range domainCh {
+			out <- toModelNotification(notification)
+		}
+	}()
+
+	return out, nil
+}
@@ -6,13 +6,29 @@ import (
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/barzurustami/bozor/internal/graphql/generated"
 	"github.com/barzurustami/bozor/internal/graphql/resolvers"
 	"github.com/gorilla/websocket"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
-func NewServer(resolver *resolvers.Resolver, maxUploadBytes int64) *handler.Server {
+// Options configures the GraphQL server.
+type Options struct {
+	MaxUploadBytes int64
+	// ExposeInternalErrors shows the message of unexpected errors to clients.
+	// It is meant for local development only.
+	ExposeInternalErrors bool
+	Introspection        bool
+	// ComplexityLimit and DepthLimit are disabled when zero.
+	ComplexityLimit int
+	DepthLimit      int
+	// PersistedQueries enables automatic persisted queries when set.
+	PersistedQueries *PersistedQueryCache
+}
+
+func NewServer(resolver *resolvers.Resolver, opts Options) *handler.Server {
 	srv := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: resolver}))
 	// Allow standard transports + multipart for file uploads.
 	srv.AddTransport(transport.Options{})
@@ -24,8 +40,29 @@ func NewServer(resolver *resolvers.Resolver, maxUploadBytes int64) *handler.Serv
 	})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
-	srv.AddTransport(transport.MultipartForm{MaxMemory: maxUploadBytes})
-	srv.Use(extension.Introspection{})
+	srv.AddTransport(transport.MultipartForm{MaxMemory: opts.MaxUploadBytes})
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+
+	if opts.Introspection {
+		srv.Use(extension.Introspection{})
+	}
+	if opts.PersistedQueries != nil {
+		srv.Use(extension.AutomaticPersistedQuery{Cache: opts.PersistedQueries})
+	}
+	if opts.ComplexityLimit > 0 {
+		srv.Use(extension.FixedComplexityLimit(opts.ComplexityLimit))
+	}
+	if opts.DepthLimit > 0 {
+		srv.Use(DepthLimit{Limit: opts.DepthLimit})
+	}
+	if opts.PersistedQueries != nil {
+		// Registered after the limits so rejected documents are not stored.
+		srv.Use(opts.PersistedQueries)
+	}
+	srv.Use(Loaders{Sources: resolver.Loaders})
+	srv.Use(Metrics{})
+	srv.Use(Tracing{})
+	srv.SetErrorPresenter(NewErrorPresenter(opts.ExposeInternalErrors))
 
 	return srv
 }
@@ -0,0 +1,70 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/barzurustami/bozor/internal/tracing"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Tracing starts a span per operation and per resolver-backed field.
+type Tracing struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = Tracing{}
+
+func (Tracing) ExtensionName() string {
+	return "Tracing"
+}
+
+func (Tracing) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (Tracing) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if !graphql.HasOperationContext(ctx) {
+		return next(ctx)
+	}
+
+	oc := graphql.GetOperationContext(ctx)
+	// A subscription calls this once per event; its resolver span covers the
+	// subscribe call and events are not traced individually.
+	if oc.Operation == nil || oc.Operation.Operation == ast.Subscription {
+		return next(ctx)
+	}
+
+	name := operationName(oc)
+	ctx, span := tracing.Start(ctx, "graphql."+string(oc.Operation.Operation)+" "+name)
+	span.SetAttributes(
+		attribute.String("graphql.operation.type", string(oc.Operation.Operation)),
+		attribute.String("graphql.operation.name", name),
+	)
+	defer span.End()
+
+	resp := next(ctx)
+	if resp != nil && len(resp.Errors) > 0 {
+		span.SetStatus(codes.Error, resp.Errors.Error())
+	}
+	return resp
+}
+
+func (Tracing) InterceptField(ctx context.Context, next graphql.Resolver) (any, error) {
+	fc := graphql.GetFieldContext(ctx)
+	// Plain struct fields are not worth a span each.
+	if fc == nil || !fc.IsResolver {
+		return next(ctx)
+	}
+
+	ctx, span := tracing.Start(ctx, "graphql.resolve "+fc.Object+"."+fc.Field.Name)
+	span.SetAttributes(attribute.String("graphql.field.path", fc.Path().String()))
+
+	res, err := next(ctx)
+	tracing.End(span, err)
+	return res, err
+}
@@ -0,0 +1,93 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/barzurustami/bozor/internal/graphql/resolvers"
+	"github.com/barzurustami/bozor/internal/middleware"
+	"github.com/barzurustami/bozor/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewProvider(tracing.Config{ServiceName: "bozor-test", SampleRatio: 1}, exporter)
+	tracing.Install(provider)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	srv := NewServer(&resolvers.Resolver{}, Options{})
+	h := middleware.RequestID(middleware.Tracing(srv))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"query EventTypes { webhookEventTypes }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", "req-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body.String())
+	}
+
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	spans := exporter.GetSpans()
+
+	find := func(name string) tracetest.SpanStub {
+		t.Helper()
+		for _, span := range spans {
+			if span.Name == name {
+				return span
+			}
+		}
+		t.Fatalf("no span %q among %d spans", name, len(spans))
+		return tracetest.SpanStub{}
+	}
+
+	httpSpan := find("POST /graphql")
+	operation := find("graphql.query EventTypes")
+	resolver := find("graphql.resolve Query.webhookEventTypes")
+
+	wantAttrs(t, httpSpan.Attributes, map[attribute.Key]attribute.Value{
+		"http.request.method":       attribute.StringValue("POST"),
+		"http.route":                attribute.StringValue("/graphql"),
+		"request.id":                attribute.StringValue("req-1"),
+		"http.response.status_code": attribute.IntValue(http.StatusOK),
+	})
+	wantAttrs(t, operation.Attributes, map[attribute.Key]attribute.Value{
+		"graphql.operation.type": attribute.StringValue("query"),
+		"graphql.operation.name": attribute.StringValue("EventTypes"),
+	})
+	wantAttrs(t, resolver.Attributes, map[attribute.Key]attribute.Value{
+		"graphql.field.path": attribute.StringValue("webhookEventTypes"),
+	})
+
+	if operation.Parent.SpanID() != httpSpan.SpanContext.SpanID() {
+		t.Errorf("operation span parent = %s, want HTTP span %s", operation.Parent.SpanID(), httpSpan.SpanContext.SpanID())
+	}
+	if resolver.Parent.SpanID() != operation.SpanContext.SpanID() {
+		t.Errorf("resolver span parent = %s, want operation span %s", resolver.Parent.SpanID(), operation.SpanContext.SpanID())
+	}
+	if resolver.SpanContext.TraceID() != httpSpan.SpanContext.TraceID() {
+		t.Error("resolver span is not in the request's trace")
+	}
+}
+
+func wantAttrs(t *testing.T, got []attribute.KeyValue, want map[attribute.Key]attribute.Value) {
+	t.Helper()
+	set := attribute.NewSet(got...)
+	for key, value := range want {
+		v, ok := set.Value(key)
+		if !ok {
+			t.Errorf("missing attribute %s", key)
+			continue
+		}
+		if v != value {
+			t.Errorf("attribute %s = %v, want %v", key, v.Emit(), value.Emit())
+		}
+	}
+}
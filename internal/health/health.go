@@ -0,0 +1,122 @@
+// Package health serves the liveness and readiness probes.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check reports whether a dependency is usable. It must honour ctx, which
+// carries the per-check timeout.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+type CheckResult struct {
+	Status     string `json:"status"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+)
+
+// Checker runs the readiness checks. It starts out ready; SetReady(false)
+// makes /readyz fail without running any checks, e.g. while shutting down.
+type Checker struct {
+	timeout time.Duration
+	checks  []namedCheck
+	ready   atomic.Bool
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	c := &Checker{timeout: timeout}
+	c.ready.Store(true)
+	return c
+}
+
+// Register adds a readiness check. It is not safe to call once the handlers
+// are serving.
+func (c *Checker) Register(name string, check Check) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+func (c *Checker) SetReady(ready bool) {
+	c.ready.Store(ready)
+}
+
+// Run executes every check concurrently, each bounded by the checker timeout.
+func (c *Checker) Run(ctx context.Context) Report {
+	report := Report{Status: statusOK, Checks: make(map[string]CheckResult, len(c.checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, nc := range c.checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := nc.check(checkCtx)
+			result := CheckResult{Status: statusOK, DurationMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				result.Status = statusFail
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			report.Checks[nc.name] = result
+			if err != nil {
+				report.Status = statusFail
+			}
+			mu.Unlock()
+		}(nc)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// Liveness answers /healthz: the process is up and serving HTTP.
+func (c *Checker) Liveness() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, Report{Status: statusOK})
+	})
+}
+
+// Readiness answers /readyz with the result of every check.
+func (c *Checker) Readiness() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !c.ready.Load() {
+			writeReport(w, Report{Status: "shutting_down"})
+			return
+		}
+		writeReport(w, c.Run(r.Context()))
+	})
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Status != statusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(report)
+}
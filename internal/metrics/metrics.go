@@ -0,0 +1,81 @@
+// Package metrics defines the Prometheus collectors exported on /metrics.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "bozor"
+
+// Registry holds every collector served by Handler. A dedicated registry keeps
+// metrics from imported libraries out unless they are added here.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	GraphQLOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "graphql_operation_duration_seconds",
+		Help:      "GraphQL operation latency by operation name and type.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "type"})
+
+	GraphQLResolverErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "graphql_resolver_errors_total",
+		Help:      "Resolver errors by operation and field.",
+	}, []string{"operation", "field"})
+
+	SubscriptionsActive = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "subscriptions_active",
+		Help:      "Open chat subscriptions by type.",
+	}, []string{"type"})
+
+	SubscriptionEventsDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "subscription_events_dropped_total",
+		Help:      "Events not delivered because the subscriber's buffer was full.",
+	}, []string{"type"})
+
+	SMSSends = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sms_sends_total",
+		Help:      "SMS send attempts by provider and outcome.",
+	}, []string{"provider", "outcome"})
+
+	PushSends = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "push_sends_total",
+		Help:      "Push send attempts by provider and outcome.",
+	}, []string{"provider", "outcome"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		GraphQLOperationDuration,
+		GraphQLResolverErrors,
+		SubscriptionsActive,
+		SubscriptionEventsDropped,
+		SMSSends,
+		PushSends,
+	)
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
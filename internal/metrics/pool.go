@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector reads pgxpool statistics at scrape time.
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	acquireCount      *prometheus.Desc
+	emptyAcquireCount *prometheus.Desc
+	acquireWait       *prometheus.Desc
+}
+
+// RegisterPool exports the pool's connection and acquire statistics.
+func RegisterPool(pool *pgxpool.Pool) {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	Registry.MustRegister(&poolCollector{
+		pool:              pool,
+		acquiredConns:     desc("acquired_conns", "Connections currently checked out of the pool."),
+		idleConns:         desc("idle_conns", "Idle connections in the pool."),
+		totalConns:        desc("total_conns", "Open connections in the pool."),
+		maxConns:          desc("max_conns", "Maximum size of the pool."),
+		acquireCount:      desc("acquires_total", "Successful connection acquires."),
+		emptyAcquireCount: desc("empty_acquires_total", "Acquires that had to wait because the pool was empty."),
+		acquireWait:       desc("acquire_wait_seconds_total", "Time spent waiting for a connection."),
+	})
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.emptyAcquireCount
+	ch <- c.acquireWait
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireWait, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
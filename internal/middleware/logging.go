@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"bufio"
+	"fmt"
+	"net"
 	"net/http"
 	"time"
 
@@ -10,8 +13,9 @@ import (
 
 type responseWriter struct {
 	http.ResponseWriter
-	status int
-	size   int
+	status   int
+	size     int
+	hijacked bool
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
@@ -28,6 +32,30 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Hijack lets WebSocket upgrades through the wrapper.
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	conn, buf, err := hijacker.Hijack()
+	if err == nil {
+		rw.hijacked = true
+		rw.status = http.StatusSwitchingProtocols
+	}
+	return conn, buf, err
+}
+
+func (rw *responseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 func Logging(base *zap.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
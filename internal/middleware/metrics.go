@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/metrics"
+)
+
+// Metrics records request latency per route. Upgraded WebSocket connections
+// are skipped; their lifetime is not a request latency.
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r)
+
+		if rw.hijacked {
+			return
+		}
+		if rw.status == 0 {
+			rw.status = http.StatusOK
+		}
+
+		metrics.HTTPRequestDuration.
+			WithLabelValues(routeLabel(r.URL.Path), r.Method, strconv.Itoa(rw.status)).
+			Observe(time.Since(start).Seconds())
+	})
+}
+
+// routeLabel maps a path onto the fixed set of routes so that arbitrary URLs
+// do not create new series.
+func routeLabel(path string) string {
+	switch {
+	case path == "/graphql", path == "/healthz", path == "/readyz", path == "/metrics", path == "/":
+		return path
+	case strings.HasPrefix(path, "/uploads/"):
+		return "/uploads/"
+	default:
+		return "other"
+	}
+}
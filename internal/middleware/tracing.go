@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/barzurustami/bozor/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing starts a server span per request, continuing any trace passed in by
+// the caller. It must run inside RequestID so the span carries the request id.
+func Tracing(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		route := routeLabel(r.URL.Path)
+		ctx, span := tracing.Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", r.URL.Path),
+				tracing.RequestIDKey.String(RequestIDFromContext(r.Context())),
+			),
+		)
+		defer span.End()
+
+		rw := &responseWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r.WithContext(ctx))
+
+		if rw.status == 0 {
+			rw.status = http.StatusOK
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", rw.status))
+		if rw.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rw.status))
+		}
+	})
+}
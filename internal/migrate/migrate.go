@@ -0,0 +1,340 @@
+// Package migrate applies the embedded SQL migrations and records them in the
+// schema_migrations table.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockKey identifies the advisory lock held while migrating so that several
+// instances starting at once apply each migration exactly once.
+const lockKey int64 = 0x626f7a6f72 // "bozor"
+
+var (
+	ErrNoDownScript   = errors.New("migration has no down script")
+	ErrUnknownVersion = errors.New("database has migrations unknown to this binary")
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(.+?)(\.down)?\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+	HasDown bool
+}
+
+type Status struct {
+	Migration Migration
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+func New(pool *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{pool: pool, migrations: migrations}, nil
+}
+
+// Load reads NNN_name.sql and NNN_name.down.sql files from fsys, ordered by
+// version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, match[2])
+		}
+		if match[3] != "" {
+			m.Down = string(body)
+			m.HasDown = true
+		} else {
+			m.Up = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies every pending migration and returns the ones it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			if err := apply(ctx, conn, migration, true); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the latest steps applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if !migration.HasDown {
+				return fmt.Errorf("%d_%s: %w", migration.Version, migration.Name, ErrNoDownScript)
+			}
+			if err := apply(ctx, conn, migration, false); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Redo reverts and reapplies the latest applied migration.
+func (m *Migrator) Redo(ctx context.Context) (*Migration, error) {
+	var redone *Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if !migration.HasDown {
+				return fmt.Errorf("%d_%s: %w", migration.Version, migration.Name, ErrNoDownScript)
+			}
+			if err := apply(ctx, conn, migration, false); err != nil {
+				return err
+			}
+			if err := apply(ctx, conn, migration, true); err != nil {
+				return err
+			}
+			redone = &migration
+			return nil
+		}
+		return nil
+	})
+	return redone, err
+}
+
+// Status lists every known migration with the time it was applied, if it was.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	done, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if appliedAt, ok := done[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}
+
+// Pending reports how many known migrations have not been applied yet. It
+// fails with ErrUnknownVersion when the database is ahead of this binary.
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+
+	done, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+
+	known := make(map[int64]struct{}, len(m.migrations))
+	pending := 0
+	for _, migration := range m.migrations {
+		known[migration.Version] = struct{}{}
+		if _, ok := done[migration.Version]; !ok {
+			pending++
+		}
+	}
+	for version := range done {
+		if _, ok := known[version]; !ok {
+			return pending, ErrUnknownVersion
+		}
+	}
+	return pending, nil
+}
+
+// withLock runs fn on a dedicated connection holding the migration advisory
+// lock. Other instances block on the lock and then find nothing pending.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		// The connection goes back to the pool, so the session lock must be
+		// released explicitly even if ctx is already cancelled.
+		_, _ = conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+	}()
+
+	if err := ensureTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func ensureTable(ctx context.Context, conn *pgxpool.Conn) error {
+	const query = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL
+		)
+	`
+	_, err := conn.Exec(ctx, query)
+	return err
+}
+
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int64]time.Time, error) {
+	// Status and Pending must not create the table, so a database that was
+	// never migrated simply has nothing applied.
+	var exists bool
+	if err := conn.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return nil, err
+	}
+	done := make(map[int64]time.Time)
+	if !exists {
+		return done, nil
+	}
+
+	const query = `
+		SELECT version, applied_at
+		FROM schema_migrations
+	`
+	rows, err := conn.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		done[version] = appliedAt
+	}
+	return done, rows.Err()
+}
+
+// apply runs one direction of a migration and updates schema_migrations in the
+// same transaction.
+func apply(ctx context.Context, conn *pgxpool.Conn, migration Migration, up bool) error {
+	script, direction := migration.Up, "up"
+	if !up {
+		script, direction = migration.Down, "down"
+	}
+
+	err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		// Without arguments pgx uses the simple protocol, which accepts
+		// several statements in one script.
+		if _, err := tx.Exec(ctx, script); err != nil {
+			return err
+		}
+		if up {
+			_, err := tx.Exec(ctx,
+				`INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)`,
+				migration.Version, migration.Name, time.Now().UTC(),
+			)
+			return err
+		}
+		_, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("migrate %s %d_%s: %w", direction, migration.Version, migration.Name, err)
+	}
+	return nil
+}
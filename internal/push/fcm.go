@@ -0,0 +1,93 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// FCMSender sends through an FCM-style HTTP gateway: one JSON request per
+// device, authorized with a server key.
+type FCMSender struct {
+	endpoint  string
+	serverKey string
+	client    *http.Client
+}
+
+func NewFCMSender(endpoint, serverKey string, timeout time.Duration) *FCMSender {
+	return &FCMSender{
+		endpoint:  endpoint,
+		serverKey: serverKey,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+type fcmRequest struct {
+	To           string            `json:"to"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type fcmResponse struct {
+	Failure int `json:"failure"`
+	Results []struct {
+		Error string `json:"error"`
+	} `json:"results"`
+}
+
+func (s *FCMSender) Send(ctx context.Context, message Message) error {
+	body, err := json.Marshal(fcmRequest{
+		To:           message.Token,
+		Notification: fcmNotification{Title: message.Title, Body: message.Body},
+		Data:         message.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "key="+s.serverKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("push gateway returned %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+
+	var result fcmResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode push gateway response: %w", err)
+	}
+	if result.Failure == 0 {
+		return nil
+	}
+
+	var reason string
+	if len(result.Results) > 0 {
+		reason = result.Results[0].Error
+	}
+	switch reason {
+	case "NotRegistered", "InvalidRegistration", "MismatchSenderId":
+		return ErrInvalidToken
+	default:
+		return fmt.Errorf("push gateway rejected message: %s", reason)
+	}
+}
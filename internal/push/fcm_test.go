@@ -0,0 +1,77 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFCMSenderSend(t *testing.T) {
+	var got fcmRequest
+	var auth string
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"success":1,"failure":0,"results":[{"message_id":"1"}]}`))
+	}))
+	defer gateway.Close()
+
+	sender := NewFCMSender(gateway.URL, "secret", time.Second)
+	err := sender.Send(context.Background(), Message{
+		Token: "device-1",
+		Title: "Aziz",
+		Body:  "Is it still for sale?",
+		Data:  map[string]string{"chat_id": "c1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if auth != "key=secret" {
+		t.Errorf("Authorization = %q, want %q", auth, "key=secret")
+	}
+	if got.To != "device-1" || got.Notification.Title != "Aziz" || got.Notification.Body != "Is it still for sale?" {
+		t.Errorf("request = %+v", got)
+	}
+	if got.Data["chat_id"] != "c1" {
+		t.Errorf("data = %v, want chat_id c1", got.Data)
+	}
+}
+
+func TestFCMSenderErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantInvalid bool
+	}{
+		{"not registered", http.StatusOK, `{"failure":1,"results":[{"error":"NotRegistered"}]}`, true},
+		{"invalid registration", http.StatusOK, `{"failure":1,"results":[{"error":"InvalidRegistration"}]}`, true},
+		{"unavailable", http.StatusOK, `{"failure":1,"results":[{"error":"Unavailable"}]}`, false},
+		{"gateway error", http.StatusInternalServerError, `boom`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer gateway.Close()
+
+			err := NewFCMSender(gateway.URL, "secret", time.Second).Send(context.Background(), Message{Token: "device-1"})
+			if err == nil {
+				t.Fatal("Send succeeded, want an error")
+			}
+			if got := errors.Is(err, ErrInvalidToken); got != tt.wantInvalid {
+				t.Errorf("errors.Is(err, ErrInvalidToken) = %v, want %v (err %v)", got, tt.wantInvalid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package push
+
+import (
+	"context"
+	"errors"
+
+	"github.com/barzurustami/bozor/internal/metrics"
+	"github.com/barzurustami/bozor/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentedSender traces sends and counts their outcomes per provider.
+type InstrumentedSender struct {
+	next     Sender
+	provider string
+}
+
+func NewInstrumentedSender(next Sender, provider string) *InstrumentedSender {
+	return &InstrumentedSender{next: next, provider: provider}
+}
+
+func (s *InstrumentedSender) Send(ctx context.Context, message Message) error {
+	ctx, span := tracing.Start(ctx, "push.Send", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("push.provider", s.provider)))
+	err := s.next.Send(ctx, message)
+	tracing.End(span, err)
+
+	outcome := "sent"
+	switch {
+	case errors.Is(err, ErrInvalidToken):
+		outcome = "invalid_token"
+	case err != nil:
+		outcome = "failed"
+	}
+	metrics.PushSends.WithLabelValues(s.provider, outcome).Inc()
+	return err
+}
@@ -0,0 +1,24 @@
+package push
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/logger"
+	"go.uber.org/zap"
+)
+
+type MockSender struct{}
+
+func NewMockSender() *MockSender {
+	return &MockSender{}
+}
+
+func (s *MockSender) Send(ctx context.Context, message Message) error {
+	logger.FromContext(ctx).Info(
+		"push mock send",
+		zap.String("title", message.Title),
+		zap.String("body", message.Body),
+		zap.Any("data", message.Data),
+	)
+	return nil
+}
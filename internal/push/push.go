@@ -0,0 +1,24 @@
+// Package push delivers notifications to mobile devices.
+package push
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidToken is returned when the provider no longer accepts a device
+// token; callers should forget the token.
+var ErrInvalidToken = errors.New("push token is no longer valid")
+
+// Message is a single push notification addressed to one device.
+type Message struct {
+	Token string
+	Title string
+	Body  string
+	// Data is passed to the app untouched, e.g. to open the right chat.
+	Data map[string]string
+}
+
+type Sender interface {
+	Send(ctx context.Context, message Message) error
+}
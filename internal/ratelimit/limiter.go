@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter allows up to limit events per key within a fixed window. State is
+// kept in memory, so limits apply per process.
+type Limiter struct {
+	limit  int
+	window time.Duration
+
+	mu        sync.Mutex
+	windows   map[string]*window
+	nextSweep time.Time
+}
+
+type window struct {
+	count   int
+	resetAt time.Time
+}
+
+// New returns a limiter allowing limit events per window. A non-positive
+// limit disables limiting.
+func New(limit int, per time.Duration) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		window:  per,
+		windows: make(map[string]*window),
+	}
+}
+
+// Allow records an event for key and reports whether it is within the limit.
+func (l *Limiter) Allow(key string) bool {
+	if l.limit <= 0 || l.window <= 0 {
+		return true
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		w = &window{resetAt: now.Add(l.window)}
+		l.windows[key] = w
+	}
+	if w.count >= l.limit {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// sweep drops expired windows at most once per window so idle keys do not
+// accumulate.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Before(l.nextSweep) {
+		return
+	}
+	for key, w := range l.windows {
+		if !now.Before(w.resetAt) {
+			delete(l.windows, key)
+		}
+	}
+	l.nextSweep = now.Add(l.window)
+}
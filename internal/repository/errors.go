@@ -1,5 +1,5 @@
 package repository
 
-import "errors"
+import "github.com/barzurustami/bozor/internal/apperr"
 
-var ErrNotFound = errors.New("not found")
+var ErrNotFound = apperr.New(apperr.CodeNotFound, "not found")
@@ -8,38 +8,188 @@ import (
 	"github.com/google/uuid"
 )
 
+// Transactor runs fn in a single database transaction. Repository calls made
+// with the ctx passed to fn take part in it, and AfterCommit defers side
+// effects such as subscription events until the transaction commits.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+	AfterCommit(ctx context.Context, fn func())
+}
+
 type UserRepository interface {
 	GetByPhone(ctx context.Context, phone string) (*domain.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.User, error)
 	Create(ctx context.Context, user *domain.User) error
 }
 
 type ProfileRepository interface {
 	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Profile, error)
+	ListByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]domain.Profile, error)
 	Upsert(ctx context.Context, profile *domain.Profile) error
 }
 
 type RequestRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.JobRequest, error)
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.JobRequest, error)
 	Create(ctx context.Context, req *domain.JobRequest) error
 }
 
 type PhotoRepository interface {
 	CreateMany(ctx context.Context, photos []domain.Photo) error
+	ListByRequestIDs(ctx context.Context, requestIDs []uuid.UUID) ([]domain.Photo, error)
+}
+
+// ChatFilter narrows ListByUser. A nil Archived returns archived and active
+// chats alike.
+type ChatFilter struct {
+	Archived *bool
 }
 
 type ChatRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Chat, error)
 	GetByRequestAndInitiator(ctx context.Context, requestID, initiatorID uuid.UUID) (*domain.Chat, error)
-	ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Chat, error)
+	ListByRequest(ctx context.Context, requestID uuid.UUID) ([]domain.Chat, error)
+	ListByUser(ctx context.Context, userID uuid.UUID, filter ChatFilter) ([]domain.Chat, error)
 	Create(ctx context.Context, chat *domain.Chat) error
 	UpdateLastMessageAt(ctx context.Context, chatID uuid.UUID, at time.Time) error
 }
 
+type ChatSettingsRepository interface {
+	Get(ctx context.Context, chatID, userID uuid.UUID) (*domain.ChatUserSettings, error)
+	Upsert(ctx context.Context, settings *domain.ChatUserSettings) error
+	Unarchive(ctx context.Context, chatID uuid.UUID, at time.Time) error
+}
+
 type MessageRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.ChatMessage, error)
-	ListByChat(ctx context.Context, chatID uuid.UUID, limit, offset int32) ([]domain.ChatMessage, error)
+	ListByChat(ctx context.Context, chatID, viewerID uuid.UUID, limit, offset int32) ([]domain.ChatMessage, error)
+	ListAround(ctx context.Context, chatID, viewerID uuid.UUID, cursor domain.MessageCursor, before, after int32) ([]domain.ChatMessage, error)
+	ListLatestByChat(ctx context.Context, chatID uuid.UUID, limit int32) ([]domain.ChatMessage, error)
+	Search(ctx context.Context, userID uuid.UUID, query string, chatID *uuid.UUID, after *domain.MessageCursor, limit int32) ([]domain.MessageSearchHit, error)
 	Create(ctx context.Context, message *domain.ChatMessage) error
-	MarkReadByID(ctx context.Context, messageID uuid.UUID, at time.Time) (*domain.ChatMessage, error)
-	MarkReadByChat(ctx context.Context, chatID, readerID uuid.UUID, at time.Time) ([]domain.ChatMessage, error)
+	// UpdateText replaces the text of an edited message along with its
+	// moderation status, adding reasons to those already recorded.
+	UpdateText(ctx context.Context, messageID uuid.UUID, text string, status domain.ModerationStatus, reasons []string, at time.Time) (*domain.ChatMessage, error)
+	SoftDelete(ctx context.Context, messageID uuid.UUID, at time.Time) (*domain.ChatMessage, error)
+	HideForUser(ctx context.Context, messageID, userID uuid.UUID, at time.Time) error
+	GetLatestReceived(ctx context.Context, chatID, readerID uuid.UUID) (*domain.ChatMessage, error)
+	ListReceivedBetween(ctx context.Context, chatID, readerID uuid.UUID, afterID *uuid.UUID, untilID uuid.UUID) ([]domain.ChatMessage, error)
+	CountUnread(ctx context.Context, chatID, readerID uuid.UUID) (int, error)
+	CountRecentDuplicates(ctx context.Context, senderID, chatID uuid.UUID, text string, since time.Time) (int, error)
+	// ListHeld returns held messages awaiting review, oldest first, with
+	// their moderation reasons.
+	ListHeld(ctx context.Context, limit int32) ([]domain.ChatMessage, error)
+	// Release clears the hold on a message. It returns ErrNotFound unless
+	// the message is held and not deleted.
+	Release(ctx context.Context, messageID uuid.UUID) (*domain.ChatMessage, error)
+}
+
+type ReactionRepository interface {
+	ListByMessage(ctx context.Context, messageID uuid.UUID) ([]domain.MessageReaction, error)
+	Add(ctx context.Context, reaction *domain.MessageReaction) (bool, error)
+	Remove(ctx context.Context, messageID, userID uuid.UUID, emoji string) (bool, error)
+}
+
+type AttachmentRepository interface {
+	ListByMessage(ctx context.Context, messageID uuid.UUID) ([]domain.MessageAttachment, error)
+	CreateMany(ctx context.Context, attachments []domain.MessageAttachment) error
+	DeleteByMessage(ctx context.Context, messageID uuid.UUID) ([]domain.MessageAttachment, error)
+}
+
+type ReadCursorRepository interface {
+	Get(ctx context.Context, chatID, userID uuid.UUID) (*domain.ReadCursor, error)
+	Advance(ctx context.Context, cursor *domain.ReadCursor) (bool, error)
+}
+
+type DeliveryCursorRepository interface {
+	Get(ctx context.Context, chatID, userID uuid.UUID) (*domain.DeliveryCursor, error)
+	Advance(ctx context.Context, cursor *domain.DeliveryCursor) (bool, error)
+}
+
+type ParticipantRepository interface {
+	Get(ctx context.Context, chatID, userID uuid.UUID) (*domain.ChatParticipant, error)
+	ListByChat(ctx context.Context, chatID uuid.UUID) ([]domain.ChatParticipant, error)
+	Add(ctx context.Context, participant *domain.ChatParticipant) (bool, error)
+	Remove(ctx context.Context, chatID, userID uuid.UUID) (bool, error)
+}
+
+type BlockRepository interface {
+	Create(ctx context.Context, block *domain.UserBlock) error
+	Delete(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error)
+	ExistsBetween(ctx context.Context, userID uuid.UUID, otherIDs []uuid.UUID) (bool, error)
+	BlockedByAny(ctx context.Context, blockedID uuid.UUID, blockerIDs []uuid.UUID) (bool, error)
+}
+
+type ReportRepository interface {
+	Create(ctx context.Context, report *domain.ModerationReport) error
+}
+
+type OutboxRepository interface {
+	Add(ctx context.Context, event *domain.OutboxEvent) error
+	Claim(ctx context.Context, now, leaseUntil time.Time, limit int32) ([]domain.OutboxEvent, error)
+	MarkDispatched(ctx context.Context, id uuid.UUID, at time.Time) error
+	Reschedule(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error
+	MarkFailed(ctx context.Context, id uuid.UUID, at time.Time, lastError string) error
+	IsProcessed(ctx context.Context, eventID uuid.UUID, handler string) (bool, error)
+	MarkProcessed(ctx context.Context, eventID uuid.UUID, handler string, at time.Time) error
+}
+
+type PersistedQueryRepository interface {
+	// Use returns the document stored under hash and records that it was
+	// used at at.
+	Use(ctx context.Context, hash string, at time.Time) (string, error)
+	Save(ctx context.Context, hash, query string, at time.Time) error
+	// Prune deletes documents unused since unusedSince, then the least
+	// recently used ones beyond keep, and returns how many went.
+	Prune(ctx context.Context, unusedSince time.Time, keep int) (int64, error)
+}
+
+type NotificationRepository interface {
+	// Create reports false when the user already has a notification for the
+	// same event.
+	Create(ctx context.Context, notification *domain.Notification) (bool, error)
+	ListByUser(ctx context.Context, userID uuid.UUID, after *domain.MessageCursor, limit int32) ([]domain.Notification, error)
+	// MarkRead marks the given notifications read, or all of the user's
+	// notifications when ids is nil.
+	MarkRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, at time.Time) (int64, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+type NotificationSettingsRepository interface {
+	// Get returns the settings without Preferences.
+	Get(ctx context.Context, userID uuid.UUID) (*domain.NotificationSettings, error)
+	Upsert(ctx context.Context, settings *domain.NotificationSettings) error
+	ListPreferences(ctx context.Context, userID uuid.UUID) ([]domain.NotificationPreference, error)
+	UpsertPreference(ctx context.Context, userID uuid.UUID, pref domain.NotificationPreference) error
+}
+
+type DeviceRepository interface {
+	// Upsert registers the device token, moving it to device.UserID if another
+	// user registered it before, and fills in the stored ID and CreatedAt.
+	Upsert(ctx context.Context, device *domain.Device) error
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Device, error)
+	Delete(ctx context.Context, userID uuid.UUID, token string) (bool, error)
+	DeleteByToken(ctx context.Context, token string) error
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *domain.Webhook) error
+	Update(ctx context.Context, webhook *domain.Webhook) error
+	Delete(ctx context.Context, id uuid.UUID) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+	List(ctx context.Context) ([]domain.Webhook, error)
+	ListActiveByEventType(ctx context.Context, eventType string) ([]domain.Webhook, error)
+}
+
+type WebhookDeliveryRepository interface {
+	// Create reports false when the event is already queued for the webhook.
+	Create(ctx context.Context, delivery *domain.WebhookDelivery) (bool, error)
+	// Claim hides up to limit due deliveries until leaseUntil and counts an
+	// attempt on each.
+	Claim(ctx context.Context, now, leaseUntil time.Time, limit int32) ([]domain.WebhookDelivery, error)
+	MarkSucceeded(ctx context.Context, id uuid.UUID, at time.Time, responseStatus int) error
+	Reschedule(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, responseStatus *int, lastError string) error
+	MarkFailed(ctx context.Context, id uuid.UUID, at time.Time, responseStatus *int, lastError string) error
+	ListByWebhook(ctx context.Context, webhookID uuid.UUID, limit int32) ([]domain.WebhookDelivery, error)
 }
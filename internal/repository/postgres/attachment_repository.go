@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AttachmentRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAttachmentRepository(pool *pgxpool.Pool) *AttachmentRepository {
+	return &AttachmentRepository{pool: pool}
+}
+
+func (r *AttachmentRepository) ListByMessage(ctx context.Context, messageID uuid.UUID) ([]domain.MessageAttachment, error) {
+	const query = `
+		SELECT id, message_id, path, mime_type, size_bytes, original_name, duration_ms, created_at
+		FROM message_attachments
+		WHERE message_id = $1
+		ORDER BY position ASC
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectAttachments(rows)
+}
+
+func (r *AttachmentRepository) CreateMany(ctx context.Context, attachments []domain.MessageAttachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	const query = `
+		INSERT INTO message_attachments (id, message_id, path, mime_type, size_bytes, original_name, duration_ms, position, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	for i, attachment := range attachments {
+		batch.Queue(query,
+			attachment.ID,
+			attachment.MessageID,
+			attachment.Path,
+			attachment.MimeType,
+			attachment.SizeBytes,
+			attachment.OriginalName,
+			attachment.DurationMs,
+			i,
+			attachment.CreatedAt,
+		)
+	}
+
+	br := conn(ctx, r.pool).SendBatch(ctx, batch)
+	defer br.Close()
+
+	for range attachments {
+		if _, err := br.Exec(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *AttachmentRepository) DeleteByMessage(ctx context.Context, messageID uuid.UUID) ([]domain.MessageAttachment, error) {
+	const query = `
+		DELETE FROM message_attachments
+		WHERE message_id = $1
+		RETURNING id, message_id, path, mime_type, size_bytes, original_name, duration_ms, created_at
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectAttachments(rows)
+}
+
+func collectAttachments(rows pgx.Rows) ([]domain.MessageAttachment, error) {
+	var attachments []domain.MessageAttachment
+	for rows.Next() {
+		attachment := domain.MessageAttachment{}
+		if err := rows.Scan(
+			&attachment.ID,
+			&attachment.MessageID,
+			&attachment.Path,
+			&attachment.MimeType,
+			&attachment.SizeBytes,
+			&attachment.OriginalName,
+			&attachment.DurationMs,
+			&attachment.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return attachments, nil
+}
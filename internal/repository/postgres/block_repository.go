@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BlockRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewBlockRepository(pool *pgxpool.Pool) *BlockRepository {
+	return &BlockRepository{pool: pool}
+}
+
+func (r *BlockRepository) Create(ctx context.Context, block *domain.UserBlock) error {
+	const query = `
+		INSERT INTO user_blocks (blocker_id, blocked_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, block.BlockerID, block.BlockedID, block.CreatedAt)
+	return err
+}
+
+func (r *BlockRepository) Delete(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error) {
+	const query = `
+		DELETE FROM user_blocks
+		WHERE blocker_id = $1 AND blocked_id = $2
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, blockerID, blockedID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ExistsBetween reports whether userID has blocked, or been blocked by, any of
+// otherIDs.
+func (r *BlockRepository) ExistsBetween(ctx context.Context, userID uuid.UUID, otherIDs []uuid.UUID) (bool, error) {
+	if len(otherIDs) == 0 {
+		return false, nil
+	}
+
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM user_blocks
+			WHERE (blocker_id = $1 AND blocked_id = ANY($2))
+				OR (blocked_id = $1 AND blocker_id = ANY($2))
+		)
+	`
+
+	var exists bool
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, userID, otherIDs).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// BlockedByAny reports whether any of blockerIDs has blocked blockedID.
+func (r *BlockRepository) BlockedByAny(ctx context.Context, blockedID uuid.UUID, blockerIDs []uuid.UUID) (bool, error) {
+	if len(blockerIDs) == 0 {
+		return false, nil
+	}
+
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM user_blocks
+			WHERE blocked_id = $1 AND blocker_id = ANY($2)
+		)
+	`
+
+	var exists bool
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, blockedID, blockerIDs).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
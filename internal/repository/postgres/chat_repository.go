@@ -29,7 +29,7 @@ func (r *ChatRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Cha
 	chat := domain.Chat{}
 	var lastMessageAt *time.Time
 
-	err := r.pool.QueryRow(ctx, query, id).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
 		&chat.ID,
 		&chat.RequestID,
 		&chat.CreatorID,
@@ -57,7 +57,7 @@ func (r *ChatRepository) GetByRequestAndInitiator(ctx context.Context, requestID
 	chat := domain.Chat{}
 	var lastMessageAt *time.Time
 
-	err := r.pool.QueryRow(ctx, query, requestID, initiatorID).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, requestID, initiatorID).Scan(
 		&chat.ID,
 		&chat.RequestID,
 		&chat.CreatorID,
@@ -75,15 +75,57 @@ func (r *ChatRepository) GetByRequestAndInitiator(ctx context.Context, requestID
 	return &chat, nil
 }
 
-func (r *ChatRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Chat, error) {
+func (r *ChatRepository) ListByRequest(ctx context.Context, requestID uuid.UUID) ([]domain.Chat, error) {
 	const query = `
 		SELECT id, request_id, creator_id, initiator_id, created_at, last_message_at
 		FROM chats
-		WHERE creator_id = $1 OR initiator_id = $1
-		ORDER BY COALESCE(last_message_at, created_at) DESC
+		WHERE request_id = $1
+		ORDER BY created_at ASC
 	`
 
-	rows, err := r.pool.Query(ctx, query, userID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, requestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var chats []domain.Chat
+	for rows.Next() {
+		chat := domain.Chat{}
+		if err := rows.Scan(
+			&chat.ID,
+			&chat.RequestID,
+			&chat.CreatorID,
+			&chat.InitiatorID,
+			&chat.CreatedAt,
+			&chat.LastMessageAt,
+		); err != nil {
+			return nil, err
+		}
+		chats = append(chats, chat)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return chats, nil
+}
+
+// ListByUser returns the user's chats with their settings, pinned chats first
+// and the rest by latest activity.
+func (r *ChatRepository) ListByUser(ctx context.Context, userID uuid.UUID, filter repository.ChatFilter) ([]domain.Chat, error) {
+	const query = `
+		SELECT c.id, c.request_id, c.creator_id, c.initiator_id, c.created_at, c.last_message_at,
+			s.archived_at, s.muted_until, s.pinned_at, COALESCE(s.updated_at, c.created_at)
+		FROM chats c
+		JOIN chat_participants p ON p.chat_id = c.id
+		LEFT JOIN chat_user_settings s ON s.chat_id = c.id AND s.user_id = p.user_id
+		WHERE p.user_id = $1
+			AND ($2::boolean IS NULL OR (s.archived_at IS NOT NULL) = $2)
+		ORDER BY s.pinned_at DESC NULLS LAST, COALESCE(c.last_message_at, c.created_at) DESC
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID, filter.Archived)
 	if err != nil {
 		return nil, err
 	}
@@ -101,10 +143,16 @@ func (r *ChatRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]do
 			&chat.InitiatorID,
 			&chat.CreatedAt,
 			&lastMessageAt,
+			&chat.Settings.ArchivedAt,
+			&chat.Settings.MutedUntil,
+			&chat.Settings.PinnedAt,
+			&chat.Settings.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
 		chat.LastMessageAt = lastMessageAt
+		chat.Settings.ChatID = chat.ID
+		chat.Settings.UserID = userID
 		chats = append(chats, chat)
 	}
 	if rows.Err() != nil {
@@ -120,7 +168,7 @@ func (r *ChatRepository) Create(ctx context.Context, chat *domain.Chat) error {
 		VALUES ($1, $2, $3, $4, $5, $6)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		chat.ID,
 		chat.RequestID,
 		chat.CreatorID,
@@ -138,6 +186,6 @@ func (r *ChatRepository) UpdateLastMessageAt(ctx context.Context, chatID uuid.UU
 		WHERE id = $1
 	`
 
-	_, err := r.pool.Exec(ctx, query, chatID, at)
+	_, err := conn(ctx, r.pool).Exec(ctx, query, chatID, at)
 	return err
 }
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ChatSettingsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewChatSettingsRepository(pool *pgxpool.Pool) *ChatSettingsRepository {
+	return &ChatSettingsRepository{pool: pool}
+}
+
+func (r *ChatSettingsRepository) Get(ctx context.Context, chatID, userID uuid.UUID) (*domain.ChatUserSettings, error) {
+	const query = `
+		SELECT chat_id, user_id, archived_at, muted_until, pinned_at, updated_at
+		FROM chat_user_settings
+		WHERE chat_id = $1 AND user_id = $2
+	`
+
+	settings := domain.ChatUserSettings{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, chatID, userID).Scan(
+		&settings.ChatID,
+		&settings.UserID,
+		&settings.ArchivedAt,
+		&settings.MutedUntil,
+		&settings.PinnedAt,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *ChatSettingsRepository) Upsert(ctx context.Context, settings *domain.ChatUserSettings) error {
+	const query = `
+		INSERT INTO chat_user_settings (chat_id, user_id, archived_at, muted_until, pinned_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (chat_id, user_id) DO UPDATE
+		SET archived_at = EXCLUDED.archived_at,
+			muted_until = EXCLUDED.muted_until,
+			pinned_at = EXCLUDED.pinned_at,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		settings.ChatID,
+		settings.UserID,
+		settings.ArchivedAt,
+		settings.MutedUntil,
+		settings.PinnedAt,
+		settings.UpdatedAt,
+	)
+	return err
+}
+
+// Unarchive brings a chat back to the inbox of every participant who archived
+// it without muting it.
+func (r *ChatSettingsRepository) Unarchive(ctx context.Context, chatID uuid.UUID, at time.Time) error {
+	const query = `
+		UPDATE chat_user_settings
+		SET archived_at = NULL, updated_at = $2
+		WHERE chat_id = $1
+			AND archived_at IS NOT NULL
+			AND (muted_until IS NULL OR muted_until <= $2)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, chatID, at)
+	return err
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DeliveryCursorRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewDeliveryCursorRepository(pool *pgxpool.Pool) *DeliveryCursorRepository {
+	return &DeliveryCursorRepository{pool: pool}
+}
+
+func (r *DeliveryCursorRepository) Get(ctx context.Context, chatID, userID uuid.UUID) (*domain.DeliveryCursor, error) {
+	const query = `
+		SELECT chat_id, user_id, last_delivered_message_id, delivered_at
+		FROM chat_delivery_cursors
+		WHERE chat_id = $1 AND user_id = $2
+	`
+
+	cursor := domain.DeliveryCursor{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, chatID, userID).Scan(
+		&cursor.ChatID,
+		&cursor.UserID,
+		&cursor.LastDeliveredMessageID,
+		&cursor.DeliveredAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// Advance moves the cursor forward to cursor.LastDeliveredMessageID. It never
+// moves a cursor backwards and reports whether anything changed.
+func (r *DeliveryCursorRepository) Advance(ctx context.Context, cursor *domain.DeliveryCursor) (bool, error) {
+	const query = `
+		INSERT INTO chat_delivery_cursors (chat_id, user_id, last_delivered_message_id, delivered_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (chat_id, user_id) DO UPDATE
+		SET last_delivered_message_id = EXCLUDED.last_delivered_message_id,
+			delivered_at = EXCLUDED.delivered_at
+		WHERE EXISTS (
+			SELECT 1
+			FROM chat_messages n, chat_messages o
+			WHERE n.id = EXCLUDED.last_delivered_message_id
+				AND o.id = chat_delivery_cursors.last_delivered_message_id
+				AND (n.created_at, n.id) > (o.created_at, o.id)
+		)
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query,
+		cursor.ChatID,
+		cursor.UserID,
+		cursor.LastDeliveredMessageID,
+		cursor.DeliveredAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DeviceRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewDeviceRepository(pool *pgxpool.Pool) *DeviceRepository {
+	return &DeviceRepository{pool: pool}
+}
+
+func (r *DeviceRepository) Upsert(ctx context.Context, device *domain.Device) error {
+	const query = `
+		INSERT INTO devices (id, user_id, platform, token, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (token) DO UPDATE
+		SET user_id = EXCLUDED.user_id,
+			platform = EXCLUDED.platform,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at
+	`
+
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		device.ID,
+		device.UserID,
+		device.Platform,
+		device.Token,
+		device.CreatedAt,
+		device.UpdatedAt,
+	).Scan(&device.ID, &device.CreatedAt)
+}
+
+func (r *DeviceRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Device, error) {
+	const query = `
+		SELECT id, user_id, platform, token, created_at, updated_at
+		FROM devices
+		WHERE user_id = $1
+		ORDER BY updated_at DESC
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var devices []domain.Device
+	for rows.Next() {
+		var device domain.Device
+		if err := rows.Scan(
+			&device.ID,
+			&device.UserID,
+			&device.Platform,
+			&device.Token,
+			&device.CreatedAt,
+			&device.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return devices, nil
+}
+
+func (r *DeviceRepository) Delete(ctx context.Context, userID uuid.UUID, token string) (bool, error) {
+	const query = `
+		DELETE FROM devices
+		WHERE user_id = $1 AND token = $2
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, userID, token)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *DeviceRepository) DeleteByToken(ctx context.Context, token string) error {
+	const query = `
+		DELETE FROM devices
+		WHERE token = $1
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, token)
+	return err
+}
@@ -2,6 +2,9 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
+	"html"
+	"strings"
 	"time"
 
 	"github.com/barzurustami/bozor/internal/domain"
@@ -11,6 +14,28 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// messageColumns expects chat_messages to be aliased as m. read_at and
+// delivered_at are derived from the cursors of everyone except the sender.
+const messageColumns = `
+	m.id, m.chat_id, m.sender_id, m.text, m.photo_path, m.reply_to_id, m.kind, m.payload, m.moderation_status, m.created_at,
+	(
+		SELECT MIN(c.read_at)
+		FROM chat_read_cursors c
+		JOIN chat_messages lr ON lr.id = c.last_read_message_id
+		WHERE c.chat_id = m.chat_id
+			AND c.user_id <> m.sender_id
+			AND (lr.created_at, lr.id) >= (m.created_at, m.id)
+	) AS read_at,
+	(
+		SELECT MIN(c.delivered_at)
+		FROM chat_delivery_cursors c
+		JOIN chat_messages ld ON ld.id = c.last_delivered_message_id
+		WHERE c.chat_id = m.chat_id
+			AND c.user_id <> m.sender_id
+			AND (ld.created_at, ld.id) >= (m.created_at, m.id)
+	) AS delivered_at,
+	m.edited_at, m.deleted_at`
+
 type MessageRepository struct {
 	pool *pgxpool.Pool
 }
@@ -21,21 +46,12 @@ func NewMessageRepository(pool *pgxpool.Pool) *MessageRepository {
 
 func (r *MessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ChatMessage, error) {
 	const query = `
-		SELECT id, chat_id, sender_id, text, photo_path, created_at, read_at
-		FROM chat_messages
-		WHERE id = $1
+		SELECT ` + messageColumns + `
+		FROM chat_messages m
+		WHERE m.id = $1
 	`
 
-	msg := domain.ChatMessage{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&msg.ID,
-		&msg.ChatID,
-		&msg.SenderID,
-		&msg.Text,
-		&msg.PhotoPath,
-		&msg.CreatedAt,
-		&msg.ReadAt,
-	)
+	msg, err := scanMessage(conn(ctx, r.pool).QueryRow(ctx, query, id))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, repository.ErrNotFound
@@ -46,80 +62,260 @@ func (r *MessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	return &msg, nil
 }
 
-func (r *MessageRepository) ListByChat(ctx context.Context, chatID uuid.UUID, limit, offset int32) ([]domain.ChatMessage, error) {
+func (r *MessageRepository) ListByChat(ctx context.Context, chatID, viewerID uuid.UUID, limit, offset int32) ([]domain.ChatMessage, error) {
 	const query = `
-		SELECT id, chat_id, sender_id, text, photo_path, created_at, read_at
-		FROM chat_messages
-		WHERE chat_id = $1
-		ORDER BY created_at ASC
-		LIMIT $2 OFFSET $3
+		SELECT ` + messageColumns + `
+		FROM chat_messages m
+		WHERE m.chat_id = $1
+			AND (m.moderation_status <> 'held' OR m.sender_id = $2)
+			AND NOT EXISTS (
+				SELECT 1 FROM chat_message_hides h
+				WHERE h.message_id = m.id AND h.user_id = $2
+			)
+		ORDER BY m.created_at ASC, m.id ASC
+		LIMIT $3 OFFSET $4
 	`
 
-	rows, err := r.pool.Query(ctx, query, chatID, limit, offset)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, chatID, viewerID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var messages []domain.ChatMessage
+	return collectMessages(rows)
+}
+
+// ListAround returns up to before messages preceding the cursor and up to
+// after messages starting at it, oldest first.
+func (r *MessageRepository) ListAround(ctx context.Context, chatID, viewerID uuid.UUID, cursor domain.MessageCursor, before, after int32) ([]domain.ChatMessage, error) {
+	const query = `
+		SELECT * FROM (
+			(
+				SELECT ` + messageColumns + `
+				FROM chat_messages m
+				WHERE m.chat_id = $1
+					AND (m.created_at, m.id) < ($3, $4)
+					AND (m.moderation_status <> 'held' OR m.sender_id = $2)
+					AND NOT EXISTS (
+						SELECT 1 FROM chat_message_hides h
+						WHERE h.message_id = m.id AND h.user_id = $2
+					)
+				ORDER BY m.created_at DESC, m.id DESC
+				LIMIT $5
+			)
+			UNION ALL
+			(
+				SELECT ` + messageColumns + `
+				FROM chat_messages m
+				WHERE m.chat_id = $1
+					AND (m.created_at, m.id) >= ($3, $4)
+					AND (m.moderation_status <> 'held' OR m.sender_id = $2)
+					AND NOT EXISTS (
+						SELECT 1 FROM chat_message_hides h
+						WHERE h.message_id = m.id AND h.user_id = $2
+					)
+				ORDER BY m.created_at ASC, m.id ASC
+				LIMIT $6
+			)
+		) around
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, chatID, viewerID, cursor.CreatedAt, cursor.ID, before, after)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectMessages(rows)
+}
+
+// Search finds messages matching query in chats userID participates in,
+// newest first. chatID narrows the search to one chat and after continues
+// from a previous page.
+func (r *MessageRepository) Search(ctx context.Context, userID uuid.UUID, query string, chatID *uuid.UUID, after *domain.MessageCursor, limit int32) ([]domain.MessageSearchHit, error) {
+	const sql = `
+		SELECT ` + messageColumns + `,
+			ts_headline('simple', m.text, q, $7)
+		FROM chat_messages m
+		JOIN chat_participants p ON p.chat_id = m.chat_id AND p.user_id = $1
+		CROSS JOIN websearch_to_tsquery('simple', $2) q
+		WHERE m.text_search @@ q
+			AND m.deleted_at IS NULL
+			AND (m.moderation_status <> 'held' OR m.sender_id = $1)
+			AND ($3::uuid IS NULL OR m.chat_id = $3)
+			AND ($4::timestamptz IS NULL OR (m.created_at, m.id) < ($4, $5::uuid))
+			AND NOT EXISTS (
+				SELECT 1 FROM chat_message_hides h
+				WHERE h.message_id = m.id AND h.user_id = $1
+			)
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT $6
+	`
+
+	var afterAt *time.Time
+	var afterID *uuid.UUID
+	if after != nil {
+		afterAt = &after.CreatedAt
+		afterID = &after.ID
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, userID, query, chatID, afterAt, afterID, limit, headlineOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []domain.MessageSearchHit
 	for rows.Next() {
-		msg := domain.ChatMessage{}
-		if err := rows.Scan(
-			&msg.ID,
-			&msg.ChatID,
-			&msg.SenderID,
-			&msg.Text,
-			&msg.PhotoPath,
-			&msg.CreatedAt,
-			&msg.ReadAt,
-		); err != nil {
+		var snippet string
+		msg, err := scanMessage(rows, &snippet)
+		if err != nil {
 			return nil, err
 		}
-		messages = append(messages, msg)
+		hits = append(hits, domain.MessageSearchHit{Message: msg, Snippet: highlightSnippet(snippet)})
 	}
 	if rows.Err() != nil {
 		return nil, rows.Err()
 	}
 
-	return messages, nil
+	return hits, nil
+}
+
+// ts_headline marks matches with private-use characters rather than tags, so
+// the snippet can be HTML-escaped before the real <mark> tags go in.
+const (
+	markStart = "\ue000"
+	markStop  = "\ue001"
+
+	headlineOptions = "StartSel=" + markStart + ", StopSel=" + markStop + ", MaxWords=25, MinWords=8"
+)
+
+var snippetMarks = strings.NewReplacer(markStart, "<mark>", markStop, "</mark>")
+
+// highlightSnippet escapes message text for use as HTML and turns the
+// headline markers into <mark> tags.
+func highlightSnippet(headline string) string {
+	return snippetMarks.Replace(html.EscapeString(headline))
+}
+
+// ListLatestByChat returns the newest messages of a chat, oldest first,
+// regardless of per-user hiding.
+func (r *MessageRepository) ListLatestByChat(ctx context.Context, chatID uuid.UUID, limit int32) ([]domain.ChatMessage, error) {
+	const query = `
+		SELECT * FROM (
+			SELECT ` + messageColumns + `
+			FROM chat_messages m
+			WHERE m.chat_id = $1
+			ORDER BY m.created_at DESC, m.id DESC
+			LIMIT $2
+		) latest
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, chatID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectMessages(rows)
 }
 
 func (r *MessageRepository) Create(ctx context.Context, message *domain.ChatMessage) error {
 	const query = `
-		INSERT INTO chat_messages (id, chat_id, sender_id, text, photo_path, created_at, read_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO chat_messages (id, chat_id, sender_id, text, photo_path, reply_to_id, kind, payload, moderation_status, moderation_reasons, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	var payload []byte
+	if message.System != nil {
+		encoded, err := json.Marshal(message.System)
+		if err != nil {
+			return err
+		}
+		payload = encoded
+	}
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		message.ID,
 		message.ChatID,
 		message.SenderID,
 		message.Text,
 		message.PhotoPath,
+		message.ReplyToID,
+		message.Kind,
+		payload,
+		message.ModerationStatus,
+		message.ModerationReasons,
 		message.CreatedAt,
-		message.ReadAt,
 	)
 	return err
 }
 
-func (r *MessageRepository) MarkReadByID(ctx context.Context, messageID uuid.UUID, at time.Time) (*domain.ChatMessage, error) {
+func (r *MessageRepository) UpdateText(ctx context.Context, messageID uuid.UUID, text string, status domain.ModerationStatus, reasons []string, at time.Time) (*domain.ChatMessage, error) {
 	const query = `
-		UPDATE chat_messages
-		SET read_at = $2
-		WHERE id = $1 AND read_at IS NULL
-		RETURNING id, chat_id, sender_id, text, photo_path, created_at, read_at
+		UPDATE chat_messages m
+		SET text = $2,
+			moderation_status = $3,
+			moderation_reasons = ARRAY(
+				SELECT DISTINCT reason
+				FROM unnest(COALESCE(m.moderation_reasons, '{}') || $4::text[]) AS reason
+			),
+			edited_at = $5
+		WHERE m.id = $1 AND m.deleted_at IS NULL
+		RETURNING ` + messageColumns
+
+	msg, err := scanMessage(conn(ctx, r.pool).QueryRow(ctx, query, messageID, text, status, reasons, at))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+func (r *MessageRepository) SoftDelete(ctx context.Context, messageID uuid.UUID, at time.Time) (*domain.ChatMessage, error) {
+	const query = `
+		UPDATE chat_messages m
+		SET text = '', photo_path = '', deleted_at = $2
+		WHERE m.id = $1 AND m.deleted_at IS NULL
+		RETURNING ` + messageColumns
+
+	msg, err := scanMessage(conn(ctx, r.pool).QueryRow(ctx, query, messageID, at))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+func (r *MessageRepository) HideForUser(ctx context.Context, messageID, userID uuid.UUID, at time.Time) error {
+	const query = `
+		INSERT INTO chat_message_hides (message_id, user_id, hidden_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (message_id, user_id) DO NOTHING
 	`
 
-	msg := domain.ChatMessage{}
-	err := r.pool.QueryRow(ctx, query, messageID, at).Scan(
-		&msg.ID,
-		&msg.ChatID,
-		&msg.SenderID,
-		&msg.Text,
-		&msg.PhotoPath,
-		&msg.CreatedAt,
-		&msg.ReadAt,
-	)
+	_, err := conn(ctx, r.pool).Exec(ctx, query, messageID, userID, at)
+	return err
+}
+
+func (r *MessageRepository) GetLatestReceived(ctx context.Context, chatID, readerID uuid.UUID) (*domain.ChatMessage, error) {
+	const query = `
+		SELECT ` + messageColumns + `
+		FROM chat_messages m
+		WHERE m.chat_id = $1 AND m.sender_id <> $2 AND m.moderation_status <> 'held'
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT 1
+	`
+
+	msg, err := scanMessage(conn(ctx, r.pool).QueryRow(ctx, query, chatID, readerID))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, repository.ErrNotFound
@@ -130,34 +326,160 @@ func (r *MessageRepository) MarkReadByID(ctx context.Context, messageID uuid.UUI
 	return &msg, nil
 }
 
-func (r *MessageRepository) MarkReadByChat(ctx context.Context, chatID, readerID uuid.UUID, at time.Time) ([]domain.ChatMessage, error) {
+// ListReceivedBetween returns messages not sent by readerID that follow afterID
+// (or the start of the chat when nil) up to and including untilID.
+func (r *MessageRepository) ListReceivedBetween(ctx context.Context, chatID, readerID uuid.UUID, afterID *uuid.UUID, untilID uuid.UUID) ([]domain.ChatMessage, error) {
 	const query = `
-		UPDATE chat_messages
-		SET read_at = $3
-		WHERE chat_id = $1
-			AND sender_id <> $2
-			AND read_at IS NULL
-		RETURNING id, chat_id, sender_id, text, photo_path, created_at, read_at
+		SELECT ` + messageColumns + `
+		FROM chat_messages m
+		JOIN chat_messages u ON u.id = $4
+		LEFT JOIN chat_messages a ON a.id = $3
+		WHERE m.chat_id = $1
+			AND m.sender_id <> $2
+			AND m.moderation_status <> 'held'
+			AND (m.created_at, m.id) <= (u.created_at, u.id)
+			AND (a.id IS NULL OR (m.created_at, m.id) > (a.created_at, a.id))
+		ORDER BY m.created_at ASC, m.id ASC
 	`
 
-	rows, err := r.pool.Query(ctx, query, chatID, readerID, at)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, chatID, readerID, afterID, untilID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	return collectMessages(rows)
+}
+
+func (r *MessageRepository) CountUnread(ctx context.Context, chatID, readerID uuid.UUID) (int, error) {
+	const query = `
+		SELECT COUNT(*)
+		FROM chat_messages m
+		LEFT JOIN chat_read_cursors c ON c.chat_id = m.chat_id AND c.user_id = $2
+		LEFT JOIN chat_messages lr ON lr.id = c.last_read_message_id
+		WHERE m.chat_id = $1
+			AND m.sender_id <> $2
+			AND m.deleted_at IS NULL
+			AND m.moderation_status <> 'held'
+			AND (lr.id IS NULL OR (m.created_at, m.id) > (lr.created_at, lr.id))
+	`
+
+	var count int
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, chatID, readerID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountRecentDuplicates counts the other chats in which senderID posted the
+// same text (ignoring case) since the given time.
+func (r *MessageRepository) CountRecentDuplicates(ctx context.Context, senderID, chatID uuid.UUID, text string, since time.Time) (int, error) {
+	const query = `
+		SELECT COUNT(DISTINCT m.chat_id)
+		FROM chat_messages m
+		WHERE m.sender_id = $1
+			AND m.chat_id <> $2
+			AND m.created_at >= $3
+			AND m.kind = 'user'
+			AND lower(m.text) = lower($4)
+	`
+
+	var count int
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, senderID, chatID, since, text).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *MessageRepository) ListHeld(ctx context.Context, limit int32) ([]domain.ChatMessage, error) {
+	const query = `
+		SELECT ` + messageColumns + `, COALESCE(m.moderation_reasons, '{}')
+		FROM chat_messages m
+		WHERE m.moderation_status = 'held' AND m.deleted_at IS NULL
+		ORDER BY m.created_at, m.id
+		LIMIT $1
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []domain.ChatMessage
+	for rows.Next() {
+		var reasons []string
+		msg, err := scanMessage(rows, &reasons)
+		if err != nil {
+			return nil, err
+		}
+		msg.ModerationReasons = reasons
+		messages = append(messages, msg)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return messages, nil
+}
+
+func (r *MessageRepository) Release(ctx context.Context, messageID uuid.UUID) (*domain.ChatMessage, error) {
+	const query = `
+		UPDATE chat_messages m
+		SET moderation_status = 'clean'
+		WHERE m.id = $1 AND m.moderation_status = 'held' AND m.deleted_at IS NULL
+		RETURNING ` + messageColumns
+
+	msg, err := scanMessage(conn(ctx, r.pool).QueryRow(ctx, query, messageID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+// scanMessage reads messageColumns followed by any extra selected columns.
+func scanMessage(row pgx.Row, extra ...any) (domain.ChatMessage, error) {
+	msg := domain.ChatMessage{}
+	var payload []byte
+
+	dest := []any{
+		&msg.ID,
+		&msg.ChatID,
+		&msg.SenderID,
+		&msg.Text,
+		&msg.PhotoPath,
+		&msg.ReplyToID,
+		&msg.Kind,
+		&payload,
+		&msg.ModerationStatus,
+		&msg.CreatedAt,
+		&msg.ReadAt,
+		&msg.DeliveredAt,
+		&msg.EditedAt,
+		&msg.DeletedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return msg, err
+	}
+
+	if payload != nil {
+		msg.System = &domain.SystemPayload{}
+		if err := json.Unmarshal(payload, msg.System); err != nil {
+			return msg, err
+		}
+	}
+	return msg, nil
+}
+
+func collectMessages(rows pgx.Rows) ([]domain.ChatMessage, error) {
 	var messages []domain.ChatMessage
 	for rows.Next() {
-		msg := domain.ChatMessage{}
-		if err := rows.Scan(
-			&msg.ID,
-			&msg.ChatID,
-			&msg.SenderID,
-			&msg.Text,
-			&msg.PhotoPath,
-			&msg.CreatedAt,
-			&msg.ReadAt,
-		); err != nil {
+		msg, err := scanMessage(rows)
+		if err != nil {
 			return nil, err
 		}
 		messages = append(messages, msg)
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const notificationColumns = `id, user_id, type, actor_id, request_id, chat_id, message_id, event_id, in_app, read_at, created_at`
+
+type NotificationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewNotificationRepository(pool *pgxpool.Pool) *NotificationRepository {
+	return &NotificationRepository{pool: pool}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, notification *domain.Notification) (bool, error) {
+	const query = `
+		INSERT INTO notifications (` + notificationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (event_id, user_id) DO NOTHING
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query,
+		notification.ID,
+		notification.UserID,
+		notification.Type,
+		notification.ActorID,
+		notification.RequestID,
+		notification.ChatID,
+		notification.MessageID,
+		notification.EventID,
+		notification.InApp,
+		notification.ReadAt,
+		notification.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListByUser returns the user's in-app notifications newest first, starting
+// after the given position.
+func (r *NotificationRepository) ListByUser(ctx context.Context, userID uuid.UUID, after *domain.MessageCursor, limit int32) ([]domain.Notification, error) {
+	const query = `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE user_id = $1
+			AND in_app
+			AND ($2::timestamptz IS NULL OR (created_at, id) < ($2, $3::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4
+	`
+
+	var afterAt *time.Time
+	var afterID *uuid.UUID
+	if after != nil {
+		afterAt = &after.CreatedAt
+		afterID = &after.ID
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID, afterAt, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []domain.Notification
+	for rows.Next() {
+		var notification domain.Notification
+		if err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Type,
+			&notification.ActorID,
+			&notification.RequestID,
+			&notification.ChatID,
+			&notification.MessageID,
+			&notification.EventID,
+			&notification.InApp,
+			&notification.ReadAt,
+			&notification.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, at time.Time) (int64, error) {
+	const query = `
+		UPDATE notifications
+		SET read_at = $3
+		WHERE user_id = $1
+			AND in_app
+			AND read_at IS NULL
+			AND ($2::uuid[] IS NULL OR id = ANY($2))
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, userID, ids, at)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	const query = `
+		SELECT COUNT(*)
+		FROM notifications
+		WHERE user_id = $1 AND in_app AND read_at IS NULL
+	`
+
+	var count int
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, userID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type NotificationSettingsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewNotificationSettingsRepository(pool *pgxpool.Pool) *NotificationSettingsRepository {
+	return &NotificationSettingsRepository{pool: pool}
+}
+
+func (r *NotificationSettingsRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.NotificationSettings, error) {
+	const query = `
+		SELECT user_id, push_previews, quiet_hours_enabled, quiet_hours_start, quiet_hours_end, time_zone, updated_at
+		FROM notification_settings
+		WHERE user_id = $1
+	`
+
+	settings := domain.NotificationSettings{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, userID).Scan(
+		&settings.UserID,
+		&settings.PushPreviews,
+		&settings.QuietHours.Enabled,
+		&settings.QuietHours.Start,
+		&settings.QuietHours.End,
+		&settings.QuietHours.TimeZone,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *NotificationSettingsRepository) Upsert(ctx context.Context, settings *domain.NotificationSettings) error {
+	const query = `
+		INSERT INTO notification_settings (user_id, push_previews, quiet_hours_enabled, quiet_hours_start, quiet_hours_end, time_zone, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO UPDATE
+		SET push_previews = EXCLUDED.push_previews,
+			quiet_hours_enabled = EXCLUDED.quiet_hours_enabled,
+			quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end,
+			time_zone = EXCLUDED.time_zone,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		settings.UserID,
+		settings.PushPreviews,
+		settings.QuietHours.Enabled,
+		settings.QuietHours.Start,
+		settings.QuietHours.End,
+		settings.QuietHours.TimeZone,
+		settings.UpdatedAt,
+	)
+	return err
+}
+
+func (r *NotificationSettingsRepository) ListPreferences(ctx context.Context, userID uuid.UUID) ([]domain.NotificationPreference, error) {
+	const query = `
+		SELECT channel, type, enabled
+		FROM notification_preferences
+		WHERE user_id = $1
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prefs []domain.NotificationPreference
+	for rows.Next() {
+		var pref domain.NotificationPreference
+		if err := rows.Scan(&pref.Channel, &pref.Type, &pref.Enabled); err != nil {
+			return nil, err
+		}
+		prefs = append(prefs, pref)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return prefs, nil
+}
+
+func (r *NotificationSettingsRepository) UpsertPreference(ctx context.Context, userID uuid.UUID, pref domain.NotificationPreference) error {
+	const query = `
+		INSERT INTO notification_preferences (user_id, channel, type, enabled)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, channel, type) DO UPDATE
+		SET enabled = EXCLUDED.enabled
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, userID, pref.Channel, pref.Type, pref.Enabled)
+	return err
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OutboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{pool: pool}
+}
+
+func (r *OutboxRepository) Add(ctx context.Context, event *domain.OutboxEvent) error {
+	const query = `
+		INSERT INTO outbox (id, event_type, payload, created_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $4)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, event.ID, event.Type, event.Payload, event.CreatedAt)
+	return err
+}
+
+// Claim leases up to limit due events until leaseUntil, counting the attempt.
+// Rows locked by another dispatcher are skipped, so several instances can
+// poll the same table.
+func (r *OutboxRepository) Claim(ctx context.Context, now, leaseUntil time.Time, limit int32) ([]domain.OutboxEvent, error) {
+	const query = `
+		UPDATE outbox o
+		SET attempts = o.attempts + 1, next_attempt_at = $2
+		FROM (
+			SELECT id
+			FROM outbox
+			WHERE dispatched_at IS NULL
+				AND failed_at IS NULL
+				AND next_attempt_at <= $1
+			ORDER BY created_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		) due
+		WHERE o.id = due.id
+		RETURNING o.id, o.event_type, o.payload, o.attempts, o.created_at
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.OutboxEvent
+	for rows.Next() {
+		event := domain.OutboxEvent{}
+		if err := rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.Payload,
+			&event.Attempts,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	// UPDATE ... RETURNING does not keep the subquery order.
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkDispatched(ctx context.Context, id uuid.UUID, at time.Time) error {
+	const query = `
+		UPDATE outbox
+		SET dispatched_at = $2, last_error = NULL
+		WHERE id = $1
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, id, at)
+	return err
+}
+
+func (r *OutboxRepository) Reschedule(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	const query = `
+		UPDATE outbox
+		SET next_attempt_at = $2, last_error = $3
+		WHERE id = $1
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, id, nextAttemptAt, lastError)
+	return err
+}
+
+// MarkFailed stops retrying an event that has used up its attempts.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, at time.Time, lastError string) error {
+	const query = `
+		UPDATE outbox
+		SET failed_at = $2, last_error = $3
+		WHERE id = $1
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, id, at, lastError)
+	return err
+}
+
+func (r *OutboxRepository) IsProcessed(ctx context.Context, eventID uuid.UUID, handler string) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1 FROM processed_events
+			WHERE event_id = $1 AND handler = $2
+		)
+	`
+
+	var processed bool
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, eventID, handler).Scan(&processed); err != nil {
+		return false, err
+	}
+	return processed, nil
+}
+
+func (r *OutboxRepository) MarkProcessed(ctx context.Context, eventID uuid.UUID, handler string, at time.Time) error {
+	const query = `
+		INSERT INTO processed_events (event_id, handler, processed_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (event_id, handler) DO NOTHING
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, eventID, handler, at)
+	return err
+}
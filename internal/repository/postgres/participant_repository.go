@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ParticipantRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewParticipantRepository(pool *pgxpool.Pool) *ParticipantRepository {
+	return &ParticipantRepository{pool: pool}
+}
+
+func (r *ParticipantRepository) Get(ctx context.Context, chatID, userID uuid.UUID) (*domain.ChatParticipant, error) {
+	const query = `
+		SELECT chat_id, user_id, role, joined_at
+		FROM chat_participants
+		WHERE chat_id = $1 AND user_id = $2
+	`
+
+	participant := domain.ChatParticipant{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, chatID, userID).Scan(
+		&participant.ChatID,
+		&participant.UserID,
+		&participant.Role,
+		&participant.JoinedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &participant, nil
+}
+
+func (r *ParticipantRepository) ListByChat(ctx context.Context, chatID uuid.UUID) ([]domain.ChatParticipant, error) {
+	const query = `
+		SELECT chat_id, user_id, role, joined_at
+		FROM chat_participants
+		WHERE chat_id = $1
+		ORDER BY joined_at ASC, user_id ASC
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var participants []domain.ChatParticipant
+	for rows.Next() {
+		participant := domain.ChatParticipant{}
+		if err := rows.Scan(
+			&participant.ChatID,
+			&participant.UserID,
+			&participant.Role,
+			&participant.JoinedAt,
+		); err != nil {
+			return nil, err
+		}
+		participants = append(participants, participant)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return participants, nil
+}
+
+func (r *ParticipantRepository) Add(ctx context.Context, participant *domain.ChatParticipant) (bool, error) {
+	const query = `
+		INSERT INTO chat_participants (chat_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (chat_id, user_id) DO NOTHING
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query,
+		participant.ChatID,
+		participant.UserID,
+		participant.Role,
+		participant.JoinedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *ParticipantRepository) Remove(ctx context.Context, chatID, userID uuid.UUID) (bool, error) {
+	const query = `
+		DELETE FROM chat_participants
+		WHERE chat_id = $1 AND user_id = $2
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, chatID, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
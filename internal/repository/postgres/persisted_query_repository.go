@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PersistedQueryRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPersistedQueryRepository(pool *pgxpool.Pool) *PersistedQueryRepository {
+	return &PersistedQueryRepository{pool: pool}
+}
+
+func (r *PersistedQueryRepository) Use(ctx context.Context, hash string, at time.Time) (string, error) {
+	const query = `
+		UPDATE persisted_queries
+		SET last_used_at = GREATEST(last_used_at, $2)
+		WHERE hash = $1
+		RETURNING query
+	`
+
+	var document string
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, hash, at).Scan(&document); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", repository.ErrNotFound
+		}
+		return "", err
+	}
+	return document, nil
+}
+
+func (r *PersistedQueryRepository) Save(ctx context.Context, hash, document string, at time.Time) error {
+	const query = `
+		INSERT INTO persisted_queries (hash, query, created_at, last_used_at)
+		VALUES ($1, $2, $3, $3)
+		ON CONFLICT (hash) DO UPDATE
+		SET last_used_at = GREATEST(persisted_queries.last_used_at, EXCLUDED.last_used_at)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, hash, document, at)
+	return err
+}
+
+func (r *PersistedQueryRepository) Prune(ctx context.Context, unusedSince time.Time, keep int) (int64, error) {
+	const query = `
+		DELETE FROM persisted_queries
+		WHERE last_used_at < $1
+			OR hash IN (
+				SELECT hash
+				FROM persisted_queries
+				ORDER BY last_used_at DESC
+				OFFSET $2
+			)
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, unusedSince, keep)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
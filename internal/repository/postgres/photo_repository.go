@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -31,7 +32,7 @@ func (r *PhotoRepository) CreateMany(ctx context.Context, photos []domain.Photo)
 		batch.Queue(query, photo.ID, photo.RequestID, photo.Path, photo.CreatedAt)
 	}
 
-	br := r.pool.SendBatch(ctx, batch)
+	br := conn(ctx, r.pool).SendBatch(ctx, batch)
 	defer br.Close()
 
 	for range photos {
@@ -41,3 +42,28 @@ func (r *PhotoRepository) CreateMany(ctx context.Context, photos []domain.Photo)
 	}
 	return nil
 }
+
+func (r *PhotoRepository) ListByRequestIDs(ctx context.Context, requestIDs []uuid.UUID) ([]domain.Photo, error) {
+	const query = `
+		SELECT id, request_id, path, created_at
+		FROM photos
+		WHERE request_id = ANY($1)
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, requestIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var photos []domain.Photo
+	for rows.Next() {
+		photo := domain.Photo{}
+		if err := rows.Scan(&photo.ID, &photo.RequestID, &photo.Path, &photo.CreatedAt); err != nil {
+			return nil, err
+		}
+		photos = append(photos, photo)
+	}
+	return photos, rows.Err()
+}
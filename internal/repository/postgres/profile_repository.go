@@ -28,7 +28,7 @@ func (r *ProfileRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (
 	profile := domain.Profile{}
 	var skills []string
 
-	err := r.pool.QueryRow(ctx, query, userID).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, userID).Scan(
 		&profile.ID,
 		&profile.UserID,
 		&profile.FullName,
@@ -60,7 +60,7 @@ func (r *ProfileRepository) Upsert(ctx context.Context, profile *domain.Profile)
 			updated_at = EXCLUDED.updated_at
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		profile.ID,
 		profile.UserID,
 		profile.FullName,
@@ -71,3 +71,35 @@ func (r *ProfileRepository) Upsert(ctx context.Context, profile *domain.Profile)
 	)
 	return err
 }
+
+func (r *ProfileRepository) ListByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]domain.Profile, error) {
+	const query = `
+		SELECT id, user_id, full_name, about, city, skills, updated_at
+		FROM profiles
+		WHERE user_id = ANY($1)
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := make([]domain.Profile, 0, len(userIDs))
+	for rows.Next() {
+		profile := domain.Profile{}
+		if err := rows.Scan(
+			&profile.ID,
+			&profile.UserID,
+			&profile.FullName,
+			&profile.About,
+			&profile.City,
+			&profile.Skills,
+			&profile.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, rows.Err()
+}
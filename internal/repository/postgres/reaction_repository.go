@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReactionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReactionRepository(pool *pgxpool.Pool) *ReactionRepository {
+	return &ReactionRepository{pool: pool}
+}
+
+func (r *ReactionRepository) ListByMessage(ctx context.Context, messageID uuid.UUID) ([]domain.MessageReaction, error) {
+	const query = `
+		SELECT message_id, user_id, emoji, created_at
+		FROM message_reactions
+		WHERE message_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reactions []domain.MessageReaction
+	for rows.Next() {
+		reaction := domain.MessageReaction{}
+		if err := rows.Scan(
+			&reaction.MessageID,
+			&reaction.UserID,
+			&reaction.Emoji,
+			&reaction.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, reaction)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return reactions, nil
+}
+
+func (r *ReactionRepository) Add(ctx context.Context, reaction *domain.MessageReaction) (bool, error) {
+	const query = `
+		INSERT INTO message_reactions (message_id, user_id, emoji, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (message_id, user_id, emoji) DO NOTHING
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query,
+		reaction.MessageID,
+		reaction.UserID,
+		reaction.Emoji,
+		reaction.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *ReactionRepository) Remove(ctx context.Context, messageID, userID uuid.UUID, emoji string) (bool, error) {
+	const query = `
+		DELETE FROM message_reactions
+		WHERE message_id = $1 AND user_id = $2 AND emoji = $3
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, messageID, userID, emoji)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
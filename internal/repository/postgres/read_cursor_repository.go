@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReadCursorRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReadCursorRepository(pool *pgxpool.Pool) *ReadCursorRepository {
+	return &ReadCursorRepository{pool: pool}
+}
+
+func (r *ReadCursorRepository) Get(ctx context.Context, chatID, userID uuid.UUID) (*domain.ReadCursor, error) {
+	const query = `
+		SELECT chat_id, user_id, last_read_message_id, read_at
+		FROM chat_read_cursors
+		WHERE chat_id = $1 AND user_id = $2
+	`
+
+	cursor := domain.ReadCursor{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, chatID, userID).Scan(
+		&cursor.ChatID,
+		&cursor.UserID,
+		&cursor.LastReadMessageID,
+		&cursor.ReadAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// Advance moves the cursor forward to cursor.LastReadMessageID. It never moves
+// a cursor backwards and reports whether anything changed.
+func (r *ReadCursorRepository) Advance(ctx context.Context, cursor *domain.ReadCursor) (bool, error) {
+	const query = `
+		INSERT INTO chat_read_cursors (chat_id, user_id, last_read_message_id, read_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (chat_id, user_id) DO UPDATE
+		SET last_read_message_id = EXCLUDED.last_read_message_id,
+			read_at = EXCLUDED.read_at
+		WHERE EXISTS (
+			SELECT 1
+			FROM chat_messages n, chat_messages o
+			WHERE n.id = EXCLUDED.last_read_message_id
+				AND o.id = chat_read_cursors.last_read_message_id
+				AND (n.created_at, n.id) > (o.created_at, o.id)
+		)
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query,
+		cursor.ChatID,
+		cursor.UserID,
+		cursor.LastReadMessageID,
+		cursor.ReadAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
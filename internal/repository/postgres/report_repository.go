@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReportRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReportRepository(pool *pgxpool.Pool) *ReportRepository {
+	return &ReportRepository{pool: pool}
+}
+
+func (r *ReportRepository) Create(ctx context.Context, report *domain.ModerationReport) error {
+	const query = `
+		INSERT INTO moderation_reports (id, reporter_id, chat_id, message_id, reported_user_id, reason, snapshot, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	snapshot, err := json.Marshal(report.Snapshot)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, r.pool).Exec(ctx, query,
+		report.ID,
+		report.ReporterID,
+		report.ChatID,
+		report.MessageID,
+		report.ReportedUserID,
+		report.Reason,
+		snapshot,
+		report.Status,
+		report.CreatedAt,
+	)
+	return err
+}
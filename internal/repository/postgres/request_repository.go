@@ -26,7 +26,7 @@ func (r *RequestRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	`
 
 	req := domain.JobRequest{}
-	if err := r.pool.QueryRow(ctx, query, id).Scan(
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
 		&req.ID,
 		&req.CustomerID,
 		&req.Title,
@@ -48,7 +48,7 @@ func (r *RequestRepository) Create(ctx context.Context, req *domain.JobRequest)
 		VALUES ($1, $2, $3, $4, $5, $6)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		req.ID,
 		req.CustomerID,
 		req.Title,
@@ -58,3 +58,34 @@ func (r *RequestRepository) Create(ctx context.Context, req *domain.JobRequest)
 	)
 	return err
 }
+
+func (r *RequestRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.JobRequest, error) {
+	const query = `
+		SELECT id, customer_id, title, description, address, created_at
+		FROM job_requests
+		WHERE id = ANY($1)
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := make([]domain.JobRequest, 0, len(ids))
+	for rows.Next() {
+		req := domain.JobRequest{}
+		if err := rows.Scan(
+			&req.ID,
+			&req.CustomerID,
+			&req.Title,
+			&req.Description,
+			&req.Address,
+			&req.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
+	}
+	return requests, rows.Err()
+}
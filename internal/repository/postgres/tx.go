@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// querier is the subset of pgx shared by the pool and a transaction.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+type txKey struct{}
+
+// txState is carried in the context of a unit of work. done is set once the
+// transaction has finished so contexts that outlive it fall back to the pool.
+// It is atomic because after-commit hooks may hand the context to goroutines
+// that outlive WithTx.
+type txState struct {
+	tx          pgx.Tx
+	afterCommit []func()
+	done        atomic.Bool
+}
+
+func activeTx(ctx context.Context) *txState {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok || state.done.Load() {
+		return nil
+	}
+	return state
+}
+
+// conn returns the transaction carried by ctx, or the pool when there is none,
+// so repositories join a unit of work without knowing about it.
+func conn(ctx context.Context, pool *pgxpool.Pool) querier {
+	if state := activeTx(ctx); state != nil {
+		return state.tx
+	}
+	return pool
+}
+
+type TxManager struct {
+	pool *pgxpool.Pool
+}
+
+func NewTxManager(pool *pgxpool.Pool) *TxManager {
+	return &TxManager{pool: pool}
+}
+
+// WithTx runs fn inside a transaction that is committed when fn returns nil
+// and rolled back otherwise. Calls nested inside fn reuse the outer
+// transaction.
+func (m *TxManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if activeTx(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	state := &txState{tx: tx}
+	defer func() {
+		state.done.Store(true)
+		// Rollback is a no-op once the transaction has been committed.
+		_ = tx.Rollback(context.WithoutCancel(ctx))
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	state.done.Store(true)
+	for _, hook := range state.afterCommit {
+		hook()
+	}
+	return nil
+}
+
+// AfterCommit runs fn once the transaction in ctx commits, or immediately when
+// ctx carries none. Hooks are dropped on rollback.
+func (m *TxManager) AfterCommit(ctx context.Context, fn func()) {
+	if state := activeTx(ctx); state != nil {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+	fn()
+}
@@ -31,7 +31,7 @@ func (r *UserRepository) GetByPhone(ctx context.Context, phone string) (*domain.
 		createdAt time.Time
 	)
 
-	err := r.pool.QueryRow(ctx, query, phone).Scan(&id, &phone, &createdAt)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, phone).Scan(&id, &phone, &createdAt)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, repository.ErrNotFound
@@ -58,7 +58,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 		createdAt time.Time
 	)
 
-	err := r.pool.QueryRow(ctx, query, id).Scan(&id, &phone, &createdAt)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(&id, &phone, &createdAt)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, repository.ErrNotFound
@@ -78,6 +78,30 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 		INSERT INTO users (id, phone, created_at)
 		VALUES ($1, $2, $3)
 	`
-	_, err := r.pool.Exec(ctx, query, user.ID, user.Phone, user.CreatedAt)
+	_, err := conn(ctx, r.pool).Exec(ctx, query, user.ID, user.Phone, user.CreatedAt)
 	return err
 }
+
+func (r *UserRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	const query = `
+		SELECT id, phone, created_at
+		FROM users
+		WHERE id = ANY($1)
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]domain.User, 0, len(ids))
+	for rows.Next() {
+		user := domain.User{}
+		if err := rows.Scan(&user.ID, &user.Phone, &user.CreatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
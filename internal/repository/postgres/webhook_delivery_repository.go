@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const webhookDeliveryColumns = `id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, response_status, last_error, created_at, completed_at`
+
+type WebhookDeliveryRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookDeliveryRepository(pool *pgxpool.Pool) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{pool: pool}
+}
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) (bool, error) {
+	const query = `
+		INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query,
+		delivery.ID,
+		delivery.WebhookID,
+		delivery.EventID,
+		delivery.EventType,
+		delivery.Payload,
+		delivery.Status,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *WebhookDeliveryRepository) Claim(ctx context.Context, now, leaseUntil time.Time, limit int32) ([]domain.WebhookDelivery, error) {
+	const query = `
+		UPDATE webhook_deliveries d
+		SET attempts = d.attempts + 1, next_attempt_at = $2
+		FROM (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = 'pending'
+				AND next_attempt_at <= $1
+			ORDER BY created_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		) due
+		WHERE d.id = due.id
+		RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+			d.next_attempt_at, d.response_status, d.last_error, d.created_at, d.completed_at
+	`
+
+	deliveries, err := r.list(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// UPDATE ... RETURNING does not keep the subquery order.
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+	})
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) MarkSucceeded(ctx context.Context, id uuid.UUID, at time.Time, responseStatus int) error {
+	const query = `
+		UPDATE webhook_deliveries
+		SET status = 'succeeded', completed_at = $2, response_status = $3, last_error = NULL
+		WHERE id = $1
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, id, at, responseStatus)
+	return err
+}
+
+func (r *WebhookDeliveryRepository) Reschedule(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, responseStatus *int, lastError string) error {
+	const query = `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $2, response_status = $3, last_error = $4
+		WHERE id = $1
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, id, nextAttemptAt, responseStatus, lastError)
+	return err
+}
+
+func (r *WebhookDeliveryRepository) MarkFailed(ctx context.Context, id uuid.UUID, at time.Time, responseStatus *int, lastError string) error {
+	const query = `
+		UPDATE webhook_deliveries
+		SET status = 'failed', completed_at = $2, response_status = $3, last_error = $4
+		WHERE id = $1
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, id, at, responseStatus, lastError)
+	return err
+}
+
+// ListByWebhook returns the webhook's most recent deliveries, newest first.
+func (r *WebhookDeliveryRepository) ListByWebhook(ctx context.Context, webhookID uuid.UUID, limit int32) ([]domain.WebhookDelivery, error) {
+	const query = `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	return r.list(ctx, query, webhookID, limit)
+}
+
+func (r *WebhookDeliveryRepository) list(ctx context.Context, query string, args ...any) ([]domain.WebhookDelivery, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return deliveries, nil
+}
+
+func scanWebhookDelivery(row pgx.Row) (domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.ResponseStatus,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&delivery.CompletedAt,
+	)
+	return delivery, err
+}
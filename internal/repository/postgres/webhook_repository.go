@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const webhookColumns = `id, url, secret, event_types, active, created_by, created_at, updated_at`
+
+type WebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{pool: pool}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	const query = `
+		INSERT INTO webhooks (` + webhookColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		webhook.ID,
+		webhook.URL,
+		webhook.Secret,
+		webhook.EventTypes,
+		webhook.Active,
+		webhook.CreatedBy,
+		webhook.CreatedAt,
+		webhook.UpdatedAt,
+	)
+	return err
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	const query = `
+		UPDATE webhooks
+		SET url = $2, secret = $3, event_types = $4, active = $5, updated_at = $6
+		WHERE id = $1
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query,
+		webhook.ID,
+		webhook.URL,
+		webhook.Secret,
+		webhook.EventTypes,
+		webhook.Active,
+		webhook.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
+	const query = `
+		DELETE FROM webhooks
+		WHERE id = $1
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	const query = `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE id = $1
+	`
+
+	webhook, err := scanWebhook(conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) List(ctx context.Context) ([]domain.Webhook, error) {
+	const query = `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		ORDER BY created_at ASC
+	`
+
+	return r.list(ctx, query)
+}
+
+func (r *WebhookRepository) ListActiveByEventType(ctx context.Context, eventType string) ([]domain.Webhook, error) {
+	const query = `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE active AND $1 = ANY(event_types)
+	`
+
+	return r.list(ctx, query, eventType)
+}
+
+func (r *WebhookRepository) list(ctx context.Context, query string, args ...any) ([]domain.Webhook, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []domain.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return webhooks, nil
+}
+
+func scanWebhook(row pgx.Row) (domain.Webhook, error) {
+	var webhook domain.Webhook
+	var createdBy *uuid.UUID
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.URL,
+		&webhook.Secret,
+		&webhook.EventTypes,
+		&webhook.Active,
+		&createdBy,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+	if createdBy != nil {
+		webhook.CreatedBy = *createdBy
+	}
+	return webhook, err
+}
@@ -8,20 +8,22 @@ import (
 	"sync"
 	"time"
 
+	"github.com/barzurustami/bozor/internal/apperr"
 	"github.com/barzurustami/bozor/internal/auth"
 	"github.com/barzurustami/bozor/internal/domain"
 	"github.com/barzurustami/bozor/internal/logger"
 	"github.com/barzurustami/bozor/internal/repository"
 	"github.com/barzurustami/bozor/internal/sms"
+	"github.com/barzurustami/bozor/internal/validate"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 var (
-	ErrInvalidCode  = errors.New("invalid code")
-	ErrCodeExpired  = errors.New("code expired")
-	ErrUserExists   = errors.New("user already exists")
-	ErrUserNotFound = errors.New("user not found")
+	ErrInvalidCode  = apperr.New(apperr.CodeUnauthenticated, "invalid code")
+	ErrCodeExpired  = apperr.New(apperr.CodeUnauthenticated, "code expired")
+	ErrUserExists   = apperr.New(apperr.CodeValidation, "user already exists")
+	ErrUserNotFound = apperr.New(apperr.CodeNotFound, "user not found")
 )
 
 func init() {
@@ -52,6 +54,14 @@ func NewAuthService(users repository.UserRepository, sender sms.Sender, jwtSvc *
 }
 
 func (s *AuthService) RequestCode(ctx context.Context, phone string) error {
+	// Codes are only issued to valid numbers, so Register and Login need no
+	// separate phone check.
+	v := validate.New()
+	v.Phone("phone", phone)
+	if err := v.Err(); err != nil {
+		return err
+	}
+
 	code := fmt.Sprintf("%04d", rand.Intn(10000))
 	expires := time.Now().Add(5 * time.Minute)
 
@@ -1,52 +1,172 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql"
+	"github.com/barzurustami/bozor/internal/apperr"
 	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/events"
 	"github.com/barzurustami/bozor/internal/logger"
+	"github.com/barzurustami/bozor/internal/metrics"
+	"github.com/barzurustami/bozor/internal/ratelimit"
 	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/barzurustami/bozor/internal/spam"
 	"github.com/barzurustami/bozor/internal/storage"
+	"github.com/barzurustami/bozor/internal/validate"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 var (
-	ErrChatForbidden = errors.New("chat access forbidden")
-	ErrChatSelf      = errors.New("cannot start chat with yourself")
-	ErrEmptyMessage  = errors.New("message text or photo required")
-	ErrReadOwn       = errors.New("cannot mark own message as read")
+	ErrChatForbidden = apperr.New(apperr.CodeForbidden, "chat access forbidden")
+	ErrChatSelf      = apperr.New(apperr.CodeValidation, "cannot start chat with yourself")
+	ErrEmptyMessage  = apperr.New(apperr.CodeValidation, "message text or photo required")
+	ErrReadOwn       = apperr.New(apperr.CodeValidation, "cannot mark own message as read")
+
+	ErrMessageNotOwned   = apperr.New(apperr.CodeForbidden, "only the sender can modify this message")
+	ErrMessageDeleted    = apperr.New(apperr.CodeValidation, "message has been deleted")
+	ErrEditWindowExpired = apperr.New(apperr.CodeForbidden, "message edit window has expired")
+	ErrReplyOtherChat    = apperr.New(apperr.CodeValidation, "reply target belongs to another chat")
+	ErrInvalidReaction   = apperr.New(apperr.CodeValidation, "invalid reaction")
+
+	ErrTooManyAttachments = apperr.New(apperr.CodeValidation, "too many attachments")
+	ErrInvalidAttachment  = apperr.New(apperr.CodeValidation, "invalid attachment")
+
+	ErrNotChatOwner     = apperr.New(apperr.CodeForbidden, "only the chat owner can manage participants")
+	ErrOwnerCannotLeave = apperr.New(apperr.CodeForbidden, "chat owner cannot leave the chat")
+	ErrNotParticipant   = apperr.New(apperr.CodeNotFound, "user is not a chat participant")
+
+	ErrUserBlocked = apperr.New(apperr.CodeForbidden, "user is blocked")
+
+	ErrInvalidMuteUntil = apperr.New(apperr.CodeValidation, "mute end must be in the future")
+	ErrEmptySearchQuery = apperr.New(apperr.CodeValidation, "search query is required")
+
+	ErrSystemMessage = apperr.New(apperr.CodeForbidden, "system messages cannot be modified")
+
+	ErrRateLimited = apperr.New(apperr.CodeRateLimited, "too many requests, try again later")
+)
+
+const (
+	maxReactionBytes      = 32
+	maxMessageAttachments = 10
+	maxMessageTextLen     = 4000
 )
 
+// allowedAttachmentTypes lists the sniffed content types accepted in chats.
+// Voice notes recorded as .m4a sniff as video/mp4.
+var allowedAttachmentTypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/gif",
+	"image/webp",
+	"image/heic",
+	"audio/*",
+	"video/mp4",
+	"application/pdf",
+}
+
+// AttachmentUpload is a file sent with a chat message. DurationMs is only kept
+// for audio attachments.
+type AttachmentUpload struct {
+	File       graphql.Upload
+	DurationMs *int
+}
+
 type ChatService struct {
-	chats    repository.ChatRepository
-	messages repository.MessageRepository
-	requests repository.RequestRepository
-	storage  *storage.LocalStorage
+	tx           repository.Transactor
+	chats        repository.ChatRepository
+	participants repository.ParticipantRepository
+	settings     repository.ChatSettingsRepository
+	messages     repository.MessageRepository
+	reactions    repository.ReactionRepository
+	attachments  repository.AttachmentRepository
+	readCursors  repository.ReadCursorRepository
+	deliveries   repository.DeliveryCursorRepository
+	requests     repository.RequestRepository
+	users        repository.UserRepository
+	blocks       repository.BlockRepository
+	storage      *storage.LocalStorage
+	events       *events.Publisher
+
+	editWindow     time.Duration
+	spamPolicy     spam.Policy
+	chatLimiter    *ratelimit.Limiter
+	messageLimiter *ratelimit.Limiter
+
+	mu                sync.RWMutex
+	messageSubs       subscriptions[domain.ChatMessage]
+	messageReadSubs   subscriptions[domain.ChatMessage]
+	messageUpdateSubs subscriptions[domain.ChatMessage]
+	deliverySubs      subscriptions[domain.ChatMessage]
+	reactionSubs      subscriptions[domain.ReactionEvent]
+}
 
-	mu              sync.RWMutex
-	messageSubs     map[uuid.UUID]map[chan domain.ChatMessage]struct{}
-	messageReadSubs map[uuid.UUID]map[chan domain.ChatMessage]struct{}
+// subscriptions holds the active subscriber channels per topic: a chat, or a
+// user for notifications. kind names the subscription in metrics.
+type subscriptions[T any] struct {
+	kind   string
+	topics map[uuid.UUID]map[chan T]subscriber
+}
+
+func newSubscriptions[T any](kind string) subscriptions[T] {
+	return subscriptions[T]{kind: kind, topics: make(map[uuid.UUID]map[chan T]subscriber)}
+}
+
+type subscriber struct {
+	userID uuid.UUID
+	cancel context.CancelFunc
 }
 
 func NewChatService(
+	tx repository.Transactor,
 	chats repository.ChatRepository,
+	participants repository.ParticipantRepository,
+	settings repository.ChatSettingsRepository,
 	messages repository.MessageRepository,
+	reactions repository.ReactionRepository,
+	attachments repository.AttachmentRepository,
+	readCursors repository.ReadCursorRepository,
+	deliveries repository.DeliveryCursorRepository,
 	requests repository.RequestRepository,
+	users repository.UserRepository,
+	blocks repository.BlockRepository,
 	storage *storage.LocalStorage,
+	events *events.Publisher,
+	editWindow time.Duration,
+	spamPolicy spam.Policy,
 ) *ChatService {
 	return &ChatService{
-		chats:           chats,
-		messages:        messages,
-		requests:        requests,
-		storage:         storage,
-		messageSubs:     make(map[uuid.UUID]map[chan domain.ChatMessage]struct{}),
-		messageReadSubs: make(map[uuid.UUID]map[chan domain.ChatMessage]struct{}),
+		tx:                tx,
+		chats:             chats,
+		participants:      participants,
+		settings:          settings,
+		messages:          messages,
+		reactions:         reactions,
+		attachments:       attachments,
+		readCursors:       readCursors,
+		deliveries:        deliveries,
+		requests:          requests,
+		users:             users,
+		blocks:            blocks,
+		storage:           storage,
+		events:            events,
+		editWindow:        editWindow,
+		spamPolicy:        spamPolicy,
+		chatLimiter:       ratelimit.New(spamPolicy.ChatCreateLimit, spamPolicy.ChatCreateWindow),
+		messageLimiter:    ratelimit.New(spamPolicy.MessageLimit, spamPolicy.MessageWindow),
+		messageSubs:       newSubscriptions[domain.ChatMessage]("chatMessageAdded"),
+		messageReadSubs:   newSubscriptions[domain.ChatMessage]("chatMessageRead"),
+		messageUpdateSubs: newSubscriptions[domain.ChatMessage]("chatMessageUpdated"),
+		deliverySubs:      newSubscriptions[domain.ChatMessage]("chatMessageDelivered"),
+		reactionSubs:      newSubscriptions[domain.ReactionEvent]("chatReactionChanged"),
 	}
 }
 
@@ -60,15 +180,29 @@ func (s *ChatService) CreateChat(ctx context.Context, requestID, initiatorID uui
 	if initiatorID == creatorID {
 		return nil, ErrChatSelf
 	}
+	if err := s.ensureNotBlocked(ctx, initiatorID, []uuid.UUID{creatorID}); err != nil {
+		return nil, err
+	}
 
 	chat, err := s.chats.GetByRequestAndInitiator(ctx, requestID, initiatorID)
 	if err == nil {
+		if err := s.rejoin(ctx, chat.ID, initiatorID); err != nil {
+			return nil, err
+		}
+		if err := s.loadSettings(ctx, chat, initiatorID); err != nil {
+			return nil, err
+		}
 		return chat, nil
 	}
 	if !errors.Is(err, repository.ErrNotFound) {
 		return nil, err
 	}
 
+	if !s.chatLimiter.Allow(initiatorID.String()) {
+		logger.FromContext(ctx).Warn("chat creation rate limited", zap.String("user_id", initiatorID.String()))
+		return nil, ErrRateLimited
+	}
+
 	now := time.Now().UTC()
 	chat = &domain.Chat{
 		ID:          uuid.New(),
@@ -78,7 +212,28 @@ func (s *ChatService) CreateChat(ctx context.Context, requestID, initiatorID uui
 		CreatedAt:   now,
 	}
 
-	if err := s.chats.Create(ctx, chat); err != nil {
+	participants := []domain.ChatParticipant{
+		{ChatID: chat.ID, UserID: creatorID, Role: domain.ParticipantRoleOwner, JoinedAt: now},
+		{ChatID: chat.ID, UserID: initiatorID, Role: domain.ParticipantRoleMember, JoinedAt: now},
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.chats.Create(ctx, chat); err != nil {
+			return err
+		}
+		for i := range participants {
+			if _, err := s.participants.Add(ctx, &participants[i]); err != nil {
+				return err
+			}
+		}
+		return s.events.Publish(ctx, events.TypeChatCreated, events.ChatCreated{
+			ChatID:      chat.ID,
+			RequestID:   chat.RequestID,
+			CreatorID:   chat.CreatorID,
+			InitiatorID: chat.InitiatorID,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -86,198 +241,1332 @@ func (s *ChatService) CreateChat(ctx context.Context, requestID, initiatorID uui
 	return chat, nil
 }
 
-func (s *ChatService) ListChats(ctx context.Context, userID uuid.UUID) ([]domain.Chat, error) {
-	return s.chats.ListByUser(ctx, userID)
+// rejoin adds the initiator back to their chat on a request after they left
+// it. Only one chat per request and initiator may exist, so without this they
+// could never reach the request's owner again.
+func (s *ChatService) rejoin(ctx context.Context, chatID, initiatorID uuid.UUID) error {
+	_, err := s.participants.Get(ctx, chatID, initiatorID)
+	if err == nil || !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+
+	if err := s.ensureCanJoin(ctx, chatID, initiatorID); err != nil {
+		return err
+	}
+
+	participant := &domain.ChatParticipant{
+		ChatID:   chatID,
+		UserID:   initiatorID,
+		Role:     domain.ParticipantRoleMember,
+		JoinedAt: time.Now().UTC(),
+	}
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.participants.Add(ctx, participant); err != nil {
+			return err
+		}
+		_, err := s.PostSystemMessage(ctx, chatID, initiatorID, participantEvent(domain.SystemEventParticipantAdded, initiatorID))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info(
+		"chat participant rejoined",
+		zap.String("chat_id", chatID.String()),
+		zap.String("user_id", initiatorID.String()),
+	)
+	return nil
 }
 
-func (s *ChatService) ListMessages(ctx context.Context, chatID, userID uuid.UUID, limit, offset int32) ([]domain.ChatMessage, error) {
+func (s *ChatService) ListParticipants(ctx context.Context, chatID, userID uuid.UUID) ([]domain.ChatParticipant, error) {
 	if _, err := s.ensureParticipant(ctx, chatID, userID); err != nil {
 		return nil, err
 	}
-	return s.messages.ListByChat(ctx, chatID, limit, offset)
+	return s.participants.ListByChat(ctx, chatID)
 }
 
-func (s *ChatService) SendMessage(ctx context.Context, chatID, senderID uuid.UUID, text string, file *graphql.Upload) (*domain.ChatMessage, error) {
-	if _, err := s.ensureParticipant(ctx, chatID, senderID); err != nil {
+func (s *ChatService) AddParticipant(ctx context.Context, chatID, actorID, userID uuid.UUID) (*domain.ChatParticipant, error) {
+	if err := s.ensureOwner(ctx, chatID, actorID); err != nil {
 		return nil, err
 	}
 
-	cleanText := strings.TrimSpace(text)
-	if cleanText == "" && file == nil {
-		return nil, ErrEmptyMessage
+	existing, err := s.participants.Get(ctx, chatID, userID)
+	if err == nil {
+		return existing, nil
 	}
-
-	photoPath := ""
-	if file != nil {
-		path, err := s.storage.Save(ctx, *file)
-		if err != nil {
-			return nil, err
-		}
-		photoPath = path
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
 	}
 
-	now := time.Now().UTC()
-	message := &domain.ChatMessage{
-		ID:        uuid.New(),
-		ChatID:    chatID,
-		SenderID:  senderID,
-		Text:      cleanText,
-		PhotoPath: photoPath,
-		CreatedAt: now,
-		ReadAt:    nil,
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
 	}
-
-	if err := s.messages.Create(ctx, message); err != nil {
+	if err := s.ensureCanJoin(ctx, chatID, userID); err != nil {
 		return nil, err
 	}
 
-	if err := s.chats.UpdateLastMessageAt(ctx, chatID, now); err != nil {
+	participant := &domain.ChatParticipant{
+		ChatID:   chatID,
+		UserID:   userID,
+		Role:     domain.ParticipantRoleMember,
+		JoinedAt: time.Now().UTC(),
+	}
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.participants.Add(ctx, participant); err != nil {
+			return err
+		}
+		_, err := s.PostSystemMessage(ctx, chatID, actorID, participantEvent(domain.SystemEventParticipantAdded, userID))
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	logger.FromContext(ctx).Info(
-		"chat message sent",
+		"chat participant added",
 		zap.String("chat_id", chatID.String()),
-		zap.String("sender_id", senderID.String()),
+		zap.String("user_id", userID.String()),
 	)
+	return participant, nil
+}
 
-	s.publishMessage(*message)
-	return message, nil
+func (s *ChatService) RemoveParticipant(ctx context.Context, chatID, actorID, userID uuid.UUID) error {
+	if err := s.ensureOwner(ctx, chatID, actorID); err != nil {
+		return err
+	}
+	if actorID == userID {
+		return ErrOwnerCannotLeave
+	}
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		removed, err := s.participants.Remove(ctx, chatID, userID)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return ErrNotParticipant
+		}
+		_, err = s.PostSystemMessage(ctx, chatID, actorID, participantEvent(domain.SystemEventParticipantRemoved, userID))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.dropSubscriber(chatID, userID)
+
+	logger.FromContext(ctx).Info(
+		"chat participant removed",
+		zap.String("chat_id", chatID.String()),
+		zap.String("user_id", userID.String()),
+	)
+	return nil
 }
 
-func (s *ChatService) MarkChatRead(ctx context.Context, chatID, userID uuid.UUID) ([]domain.ChatMessage, error) {
-	if _, err := s.ensureParticipant(ctx, chatID, userID); err != nil {
-		return nil, err
+func (s *ChatService) LeaveChat(ctx context.Context, chatID, userID uuid.UUID) error {
+	participant, err := s.participants.Get(ctx, chatID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrChatForbidden
+		}
+		return err
+	}
+	if participant.Role == domain.ParticipantRoleOwner {
+		return ErrOwnerCannotLeave
 	}
 
-	now := time.Now().UTC()
-	messages, err := s.messages.MarkReadByChat(ctx, chatID, userID, now)
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.participants.Remove(ctx, chatID, userID); err != nil {
+			return err
+		}
+		_, err := s.PostSystemMessage(ctx, chatID, userID, participantEvent(domain.SystemEventParticipantLeft, userID))
+		return err
+	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	for _, message := range messages {
-		s.publishRead(message)
+	s.dropSubscriber(chatID, userID)
+
+	logger.FromContext(ctx).Info(
+		"chat participant left",
+		zap.String("chat_id", chatID.String()),
+		zap.String("user_id", userID.String()),
+	)
+	return nil
+}
+
+func (s *ChatService) ListChats(ctx context.Context, userID uuid.UUID, filter repository.ChatFilter) ([]domain.Chat, error) {
+	return s.chats.ListByUser(ctx, userID, filter)
+}
+
+// ArchiveChat moves the chat out of (or back into) the user's main list. An
+// archived chat returns to the list on the next message unless it is muted.
+func (s *ChatService) ArchiveChat(ctx context.Context, chatID, userID uuid.UUID, archived bool) (*domain.Chat, error) {
+	return s.updateSettings(ctx, chatID, userID, func(settings *domain.ChatUserSettings, now time.Time) {
+		settings.ArchivedAt = nil
+		if archived {
+			settings.ArchivedAt = &now
+		}
+	})
+}
+
+// MuteChat silences the chat until the given time; nil unmutes it.
+func (s *ChatService) MuteChat(ctx context.Context, chatID, userID uuid.UUID, until *time.Time) (*domain.Chat, error) {
+	if until != nil && !until.After(time.Now()) {
+		return nil, ErrInvalidMuteUntil
 	}
 
-	return messages, nil
+	return s.updateSettings(ctx, chatID, userID, func(settings *domain.ChatUserSettings, _ time.Time) {
+		settings.MutedUntil = nil
+		if until != nil {
+			mutedUntil := until.UTC()
+			settings.MutedUntil = &mutedUntil
+		}
+	})
 }
 
-func (s *ChatService) MarkMessageRead(ctx context.Context, messageID, userID uuid.UUID) (*domain.ChatMessage, error) {
-	message, err := s.messages.GetByID(ctx, messageID)
+func (s *ChatService) PinChat(ctx context.Context, chatID, userID uuid.UUID, pinned bool) (*domain.Chat, error) {
+	return s.updateSettings(ctx, chatID, userID, func(settings *domain.ChatUserSettings, now time.Time) {
+		if !pinned {
+			settings.PinnedAt = nil
+		} else if settings.PinnedAt == nil {
+			settings.PinnedAt = &now
+		}
+	})
+}
+
+func (s *ChatService) updateSettings(ctx context.Context, chatID, userID uuid.UUID, apply func(settings *domain.ChatUserSettings, now time.Time)) (*domain.Chat, error) {
+	chat, err := s.ensureParticipant(ctx, chatID, userID)
 	if err != nil {
 		return nil, err
 	}
-
-	if _, err := s.ensureParticipant(ctx, message.ChatID, userID); err != nil {
+	if err := s.loadSettings(ctx, chat, userID); err != nil {
 		return nil, err
 	}
-	if message.SenderID == userID {
-		return nil, ErrReadOwn
-	}
-	if message.ReadAt != nil {
-		return message, nil
+
+	now := time.Now().UTC()
+	apply(&chat.Settings, now)
+	chat.Settings.UpdatedAt = now
+
+	if err := s.settings.Upsert(ctx, &chat.Settings); err != nil {
+		return nil, err
 	}
+	return chat, nil
+}
 
-	updated, err := s.messages.MarkReadByID(ctx, messageID, time.Now().UTC())
+// loadSettings fills chat.Settings for userID, falling back to defaults when
+// the user never changed them.
+func (s *ChatService) loadSettings(ctx context.Context, chat *domain.Chat, userID uuid.UUID) error {
+	settings, err := s.settings.Get(ctx, chat.ID, userID)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			return s.messages.GetByID(ctx, messageID)
+		if !errors.Is(err, repository.ErrNotFound) {
+			return err
 		}
-		return nil, err
+		settings = &domain.ChatUserSettings{ChatID: chat.ID, UserID: userID, UpdatedAt: chat.CreatedAt}
 	}
+	chat.Settings = *settings
+	return nil
+}
 
-	s.publishRead(*updated)
-	return updated, nil
+func (s *ChatService) ListMessages(ctx context.Context, chatID, userID uuid.UUID, limit, offset int32) ([]domain.ChatMessage, error) {
+	if _, err := s.ensureParticipant(ctx, chatID, userID); err != nil {
+		return nil, err
+	}
+	return s.messages.ListByChat(ctx, chatID, userID, limit, offset)
 }
 
-func (s *ChatService) SubscribeMessages(ctx context.Context, chatID, userID uuid.UUID) (<-chan domain.ChatMessage, error) {
+// ListMessagesAround returns a page of limit messages with the message at
+// cursor near its middle, so a search hit can be opened in context.
+func (s *ChatService) ListMessagesAround(ctx context.Context, chatID, userID uuid.UUID, cursor domain.MessageCursor, limit int32) ([]domain.ChatMessage, error) {
 	if _, err := s.ensureParticipant(ctx, chatID, userID); err != nil {
 		return nil, err
 	}
 
-	ch := make(chan domain.ChatMessage, 1)
+	before := limit / 2
+	return s.messages.ListAround(ctx, chatID, userID, cursor, before, limit-before)
+}
 
-	s.mu.Lock()
-	if s.messageSubs[chatID] == nil {
-		s.messageSubs[chatID] = make(map[chan domain.ChatMessage]struct{})
+// SearchMessages returns up to limit hits, newest first, and whether more
+// hits follow. A nil chatID searches every chat the user participates in.
+func (s *ChatService) SearchMessages(ctx context.Context, userID uuid.UUID, query string, chatID *uuid.UUID, after *domain.MessageCursor, limit int32) ([]domain.MessageSearchHit, bool, error) {
+	cleanQuery := strings.TrimSpace(query)
+	if cleanQuery == "" {
+		return nil, false, ErrEmptySearchQuery
 	}
-	s.messageSubs[chatID][ch] = struct{}{}
-	s.mu.Unlock()
 
-	go func() {
-		<-ctx.Done()
-		s.mu.Lock()
-		delete(s.messageSubs[chatID], ch)
-		if len(s.messageSubs[chatID]) == 0 {
-			delete(s.messageSubs, chatID)
+	if chatID != nil {
+		if _, err := s.ensureParticipant(ctx, *chatID, userID); err != nil {
+			return nil, false, err
 		}
-		s.mu.Unlock()
-		close(ch)
-	}()
+	}
+
+	hits, err := s.messages.Search(ctx, userID, cleanQuery, chatID, after, limit+1)
+	if err != nil {
+		return nil, false, err
+	}
 
-	return ch, nil
+	hasMore := len(hits) > int(limit)
+	if hasMore {
+		hits = hits[:limit]
+	}
+	return hits, hasMore, nil
 }
 
-func (s *ChatService) SubscribeReads(ctx context.Context, chatID, userID uuid.UUID) (<-chan domain.ChatMessage, error) {
-	if _, err := s.ensureParticipant(ctx, chatID, userID); err != nil {
+func (s *ChatService) GetMessage(ctx context.Context, messageID, userID uuid.UUID) (*domain.ChatMessage, error) {
+	message, err := s.messages.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.ensureParticipant(ctx, message.ChatID, userID); err != nil {
 		return nil, err
 	}
+	if message.ModerationStatus == domain.ModerationStatusHeld && message.SenderID != userID {
+		return nil, repository.ErrNotFound
+	}
+	return message, nil
+}
 
-	ch := make(chan domain.ChatMessage, 1)
+func (s *ChatService) SendMessage(ctx context.Context, chatID, senderID uuid.UUID, text string, uploads []AttachmentUpload, replyToID *uuid.UUID) (*domain.ChatMessage, error) {
+	if _, err := s.ensureParticipant(ctx, chatID, senderID); err != nil {
+		return nil, err
+	}
+	if err := s.ensureSenderNotBlocked(ctx, chatID, senderID); err != nil {
+		return nil, err
+	}
 
-	s.mu.Lock()
-	if s.messageReadSubs[chatID] == nil {
-		s.messageReadSubs[chatID] = make(map[chan domain.ChatMessage]struct{})
+	cleanText := strings.TrimSpace(text)
+	if cleanText == "" && len(uploads) == 0 {
+		return nil, ErrEmptyMessage
+	}
+	v := validate.New()
+	v.Length("text", cleanText, 0, maxMessageTextLen)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	if len(uploads) > maxMessageAttachments {
+		return nil, ErrTooManyAttachments
+	}
+	for _, upload := range uploads {
+		if upload.DurationMs != nil && *upload.DurationMs < 0 {
+			return nil, ErrInvalidAttachment
+		}
 	}
-	s.messageReadSubs[chatID][ch] = struct{}{}
-	s.mu.Unlock()
 
-	go func() {
-		<-ctx.Done()
-		s.mu.Lock()
-		delete(s.messageReadSubs[chatID], ch)
-		if len(s.messageReadSubs[chatID]) == 0 {
-			delete(s.messageReadSubs, chatID)
+	if replyToID != nil {
+		target, err := s.messages.GetByID(ctx, *replyToID)
+		if err != nil {
+			return nil, err
 		}
-		s.mu.Unlock()
-		close(ch)
-	}()
+		if target.ChatID != chatID {
+			return nil, ErrReplyOtherChat
+		}
+	}
 
-	return ch, nil
-}
+	if !s.messageLimiter.Allow(senderID.String()) {
+		logger.FromContext(ctx).Warn("chat message rate limited", zap.String("sender_id", senderID.String()))
+		return nil, ErrRateLimited
+	}
 
-func (s *ChatService) publishMessage(message domain.ChatMessage) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	verdict, err := s.checkContent(ctx, chatID, senderID, cleanText)
+	if err != nil {
+		return nil, err
+	}
 
-	for ch := range s.messageSubs[message.ChatID] {
-		select {
-		case ch <- message:
-		default:
+	now := time.Now().UTC()
+	messageID := uuid.New()
+
+	// photo_path keeps serving older clients: it points at the first image
+	// attachment only, so documents and voice notes are no longer reported as
+	// photos.
+	photoPath := ""
+	attachments := make([]domain.MessageAttachment, 0, len(uploads))
+	for i, upload := range uploads {
+		stored, err := s.storage.SaveFile(ctx, upload.File)
+		if err != nil {
+			s.removeFiles(ctx, messageID, attachmentPaths(attachments))
+			return nil, err
+		}
+		v.ContentType(fmt.Sprintf("attachments[%d].file", i), stored.ContentType, allowedAttachmentTypes)
+		if err := v.Err(); err != nil {
+			s.removeFiles(ctx, messageID, append(attachmentPaths(attachments), stored.Path))
+			return nil, err
+		}
+
+		attachment := domain.MessageAttachment{
+			ID:           uuid.New(),
+			MessageID:    messageID,
+			Path:         stored.Path,
+			MimeType:     stored.ContentType,
+			SizeBytes:    stored.Size,
+			OriginalName: filepath.Base(upload.File.Filename),
+			CreatedAt:    now,
+		}
+		if strings.HasPrefix(stored.ContentType, "audio/") {
+			attachment.DurationMs = upload.DurationMs
+		}
+		if photoPath == "" && strings.HasPrefix(stored.ContentType, "image/") {
+			photoPath = stored.Path
 		}
+		attachments = append(attachments, attachment)
 	}
-}
 
-func (s *ChatService) publishRead(message domain.ChatMessage) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	message := &domain.ChatMessage{
+		ID:        messageID,
+		ChatID:    chatID,
+		SenderID:  senderID,
+		Text:      cleanText,
+		PhotoPath: photoPath,
+		ReplyToID: replyToID,
+		Kind:      domain.MessageKindUser,
+		CreatedAt: now,
+	}
+	applyVerdict(message, verdict)
+	held := message.ModerationStatus == domain.ModerationStatusHeld
 
-	for ch := range s.messageReadSubs[message.ChatID] {
-		select {
-		case ch <- message:
-		default:
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.messages.Create(ctx, message); err != nil {
+			return err
+		}
+		if err := s.attachments.CreateMany(ctx, attachments); err != nil {
+			return err
+		}
+		// Held messages must not reorder or resurface the chat for the
+		// other participants.
+		if held {
+			return nil
+		}
+		if err := s.chats.UpdateLastMessageAt(ctx, chatID, now); err != nil {
+			return err
+		}
+		if err := s.settings.Unarchive(ctx, chatID, now); err != nil {
+			return err
 		}
+		return s.publishMessageSent(ctx, message)
+	})
+	if err != nil {
+		s.removeFiles(ctx, messageID, attachmentPaths(attachments))
+		return nil, err
+	}
+
+	if held {
+		logger.FromContext(ctx).Warn(
+			"chat message held for review",
+			zap.String("chat_id", chatID.String()),
+			zap.String("message_id", messageID.String()),
+			zap.Strings("reasons", message.ModerationReasons),
+		)
+		return message, nil
 	}
+
+	logger.FromContext(ctx).Info(
+		"chat message sent",
+		zap.String("chat_id", chatID.String()),
+		zap.String("sender_id", senderID.String()),
+		zap.Int("attachments", len(attachments)),
+		zap.String("moderation_status", string(message.ModerationStatus)),
+	)
+
+	s.publishMessage(ctx, *message)
+	return message, nil
 }
 
-func (s *ChatService) ensureParticipant(ctx context.Context, chatID, userID uuid.UUID) (*domain.Chat, error) {
-	chat, err := s.chats.GetByID(ctx, chatID)
+// PostSystemMessage records an event in the chat timeline on behalf of actorID.
+// It skips participant and block checks, so callers must have authorized the
+// action that produced the event.
+func (s *ChatService) PostSystemMessage(ctx context.Context, chatID, actorID uuid.UUID, payload domain.SystemPayload) (*domain.ChatMessage, error) {
+	now := time.Now().UTC()
+	message := &domain.ChatMessage{
+		ID:        uuid.New(),
+		ChatID:    chatID,
+		SenderID:  actorID,
+		Text:      systemMessageText(payload),
+		Kind:      domain.MessageKindSystem,
+		System:    &payload,
+		CreatedAt: now,
+	}
+	message.ModerationStatus = domain.ModerationStatusClean
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.messages.Create(ctx, message); err != nil {
+			return err
+		}
+		if err := s.chats.UpdateLastMessageAt(ctx, chatID, now); err != nil {
+			return err
+		}
+		return s.publishMessageSent(ctx, message)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if userID != chat.CreatorID && userID != chat.InitiatorID {
-		return nil, ErrChatForbidden
+
+	logger.FromContext(ctx).Info(
+		"chat system message posted",
+		zap.String("chat_id", chatID.String()),
+		zap.String("event", string(payload.Event)),
+	)
+
+	s.publishMessage(ctx, *message)
+	return message, nil
+}
+
+// PostRequestEvent posts a system message into every chat about the request.
+func (s *ChatService) PostRequestEvent(ctx context.Context, requestID, actorID uuid.UUID, payload domain.SystemPayload) error {
+	chats, err := s.chats.ListByRequest(ctx, requestID)
+	if err != nil {
+		return err
 	}
-	return chat, nil
+
+	for _, chat := range chats {
+		if _, err := s.PostSystemMessage(ctx, chat.ID, actorID, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// systemMessageText is the plain text shown by clients that do not render
+// system payloads yet.
+func systemMessageText(payload domain.SystemPayload) string {
+	switch payload.Event {
+	case domain.SystemEventParticipantAdded:
+		return "A participant joined the chat"
+	case domain.SystemEventParticipantRemoved:
+		return "A participant was removed from the chat"
+	case domain.SystemEventParticipantLeft:
+		return "A participant left the chat"
+	case domain.SystemEventRequestPhotosAdded:
+		return "New photos were added to the request"
+	default:
+		return ""
+	}
+}
+
+func (s *ChatService) ListAttachments(ctx context.Context, messageID uuid.UUID) ([]domain.MessageAttachment, error) {
+	return s.attachments.ListByMessage(ctx, messageID)
+}
+
+func (s *ChatService) EditMessage(ctx context.Context, messageID, userID uuid.UUID, text string) (*domain.ChatMessage, error) {
+	message, err := s.messages.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.ensureParticipant(ctx, message.ChatID, userID); err != nil {
+		return nil, err
+	}
+	if message.Kind == domain.MessageKindSystem {
+		return nil, ErrSystemMessage
+	}
+	if message.SenderID != userID {
+		return nil, ErrMessageNotOwned
+	}
+	if message.DeletedAt != nil {
+		return nil, ErrMessageDeleted
+	}
+
+	now := time.Now().UTC()
+	if now.Sub(message.CreatedAt) > s.editWindow {
+		return nil, ErrEditWindowExpired
+	}
+
+	cleanText := strings.TrimSpace(text)
+	v := validate.New()
+	v.Length("text", cleanText, 0, maxMessageTextLen)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	if cleanText == "" {
+		attachments, err := s.attachments.ListByMessage(ctx, messageID)
+		if err != nil {
+			return nil, err
+		}
+		if len(attachments) == 0 && message.PhotoPath == "" {
+			return nil, ErrEmptyMessage
+		}
+	}
+
+	// Edits go through the same spam checks as new messages, and never
+	// lift an earlier flag or hold.
+	verdict, err := s.checkContent(ctx, message.ChatID, userID, cleanText)
+	if err != nil {
+		return nil, err
+	}
+	checked := domain.ChatMessage{}
+	applyVerdict(&checked, verdict)
+	status := stricterStatus(message.ModerationStatus, checked.ModerationStatus)
+
+	updated, err := s.messages.UpdateText(ctx, messageID, cleanText, status, checked.ModerationReasons, now)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrMessageDeleted
+		}
+		return nil, err
+	}
+
+	// Held text is for its sender and moderators only, so other
+	// participants get no update; a message held by this edit drops out
+	// of their history.
+	if updated.ModerationStatus == domain.ModerationStatusHeld {
+		logger.FromContext(ctx).Warn(
+			"chat message edit held for review",
+			zap.String("chat_id", updated.ChatID.String()),
+			zap.String("message_id", messageID.String()),
+			zap.Strings("reasons", checked.ModerationReasons),
+		)
+		return updated, nil
+	}
+
+	logger.FromContext(ctx).Info(
+		"chat message edited",
+		zap.String("chat_id", updated.ChatID.String()),
+		zap.String("message_id", messageID.String()),
+	)
+
+	s.publishUpdate(ctx, *updated)
+	return updated, nil
+}
+
+// DeleteMessage hides the message for the caller only, or, when forEveryone is
+// set, replaces it with a tombstone visible to all participants.
+func (s *ChatService) DeleteMessage(ctx context.Context, messageID, userID uuid.UUID, forEveryone bool) error {
+	message, err := s.messages.GetByID(ctx, messageID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.ensureParticipant(ctx, message.ChatID, userID); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if !forEveryone {
+		return s.messages.HideForUser(ctx, messageID, userID, now)
+	}
+
+	if message.Kind == domain.MessageKindSystem {
+		return ErrSystemMessage
+	}
+	if message.SenderID != userID {
+		return ErrMessageNotOwned
+	}
+	if message.DeletedAt != nil {
+		return nil
+	}
+
+	var deleted *domain.ChatMessage
+	var attachments []domain.MessageAttachment
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		deleted, err = s.messages.SoftDelete(ctx, messageID, now)
+		if err != nil {
+			return err
+		}
+		attachments, err = s.attachments.DeleteByMessage(ctx, messageID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// Files go only after the commit so a rollback never leaves rows
+	// pointing at missing files.
+	s.removeFiles(ctx, messageID, append([]string{message.PhotoPath}, attachmentPaths(attachments)...))
+
+	logger.FromContext(ctx).Info(
+		"chat message deleted",
+		zap.String("chat_id", deleted.ChatID.String()),
+		zap.String("message_id", messageID.String()),
+	)
+
+	if deleted.ModerationStatus != domain.ModerationStatusHeld {
+		s.publishUpdate(ctx, *deleted)
+	}
+	return nil
+}
+
+// ListHeldMessages returns the moderation queue of held messages. Like the
+// other moderation methods below, it skips participant checks, so callers
+// must have checked that the actor is a moderator.
+func (s *ChatService) ListHeldMessages(ctx context.Context, limit int32) ([]domain.ChatMessage, error) {
+	return s.messages.ListHeld(ctx, limit)
+}
+
+// ReleaseHeldMessage approves a held message and delivers it as if it had
+// just been sent: the chat resurfaces, subscribers receive it and
+// chat.message_sent triggers notifications.
+func (s *ChatService) ReleaseHeldMessage(ctx context.Context, messageID uuid.UUID) (*domain.ChatMessage, error) {
+	now := time.Now().UTC()
+	var released *domain.ChatMessage
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		released, err = s.messages.Release(ctx, messageID)
+		if err != nil {
+			return err
+		}
+		if err := s.chats.UpdateLastMessageAt(ctx, released.ChatID, now); err != nil {
+			return err
+		}
+		if err := s.settings.Unarchive(ctx, released.ChatID, now); err != nil {
+			return err
+		}
+		return s.publishMessageSent(ctx, released)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info(
+		"held chat message released",
+		zap.String("chat_id", released.ChatID.String()),
+		zap.String("message_id", messageID.String()),
+	)
+
+	s.publishMessage(ctx, *released)
+	return released, nil
+}
+
+// RejectHeldMessage deletes a held message and its files. Other participants
+// never saw it, so no update is published; the sender sees a tombstone.
+func (s *ChatService) RejectHeldMessage(ctx context.Context, messageID uuid.UUID) error {
+	message, err := s.messages.GetByID(ctx, messageID)
+	if err != nil {
+		return err
+	}
+	if message.ModerationStatus != domain.ModerationStatusHeld || message.DeletedAt != nil {
+		return repository.ErrNotFound
+	}
+
+	var attachments []domain.MessageAttachment
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.messages.SoftDelete(ctx, messageID, time.Now().UTC()); err != nil {
+			return err
+		}
+		var err error
+		attachments, err = s.attachments.DeleteByMessage(ctx, messageID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.removeFiles(ctx, messageID, append([]string{message.PhotoPath}, attachmentPaths(attachments)...))
+
+	logger.FromContext(ctx).Info(
+		"held chat message rejected",
+		zap.String("chat_id", message.ChatID.String()),
+		zap.String("message_id", messageID.String()),
+	)
+	return nil
+}
+
+func (s *ChatService) ListReactions(ctx context.Context, messageID uuid.UUID) ([]domain.MessageReaction, error) {
+	return s.reactions.ListByMessage(ctx, messageID)
+}
+
+func (s *ChatService) AddReaction(ctx context.Context, messageID, userID uuid.UUID, emoji string) (*domain.MessageReaction, error) {
+	message, err := s.GetMessage(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if message.DeletedAt != nil {
+		return nil, ErrMessageDeleted
+	}
+
+	cleanEmoji, err := normalizeReaction(emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := &domain.MessageReaction{
+		MessageID: messageID,
+		UserID:    userID,
+		Emoji:     cleanEmoji,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	added, err := s.reactions.Add(ctx, reaction)
+	if err != nil {
+		return nil, err
+	}
+	// Only the sender can see, and so react to, a held message.
+	if added && message.ModerationStatus != domain.ModerationStatusHeld {
+		s.publishReaction(ctx, domain.ReactionEvent{ChatID: message.ChatID, Reaction: *reaction, Added: true})
+	}
+
+	return reaction, nil
+}
+
+func (s *ChatService) RemoveReaction(ctx context.Context, messageID, userID uuid.UUID, emoji string) error {
+	message, err := s.GetMessage(ctx, messageID, userID)
+	if err != nil {
+		return err
+	}
+
+	cleanEmoji, err := normalizeReaction(emoji)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.reactions.Remove(ctx, messageID, userID, cleanEmoji)
+	if err != nil {
+		return err
+	}
+	if removed && message.ModerationStatus != domain.ModerationStatusHeld {
+		s.publishReaction(ctx, domain.ReactionEvent{
+			ChatID: message.ChatID,
+			Reaction: domain.MessageReaction{
+				MessageID: messageID,
+				UserID:    userID,
+				Emoji:     cleanEmoji,
+				CreatedAt: time.Now().UTC(),
+			},
+			Added: false,
+		})
+	}
+
+	return nil
+}
+
+func (s *ChatService) MarkChatRead(ctx context.Context, chatID, userID uuid.UUID) ([]domain.ChatMessage, error) {
+	if _, err := s.ensureParticipant(ctx, chatID, userID); err != nil {
+		return nil, err
+	}
+
+	latest, err := s.messages.GetLatestReceived(ctx, chatID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	messages, err := s.advanceReadCursor(ctx, chatID, userID, latest.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, message := range messages {
+		s.publishRead(ctx, message)
+	}
+
+	return messages, nil
+}
+
+func (s *ChatService) MarkMessageRead(ctx context.Context, messageID, userID uuid.UUID) (*domain.ChatMessage, error) {
+	message, err := s.GetMessage(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if message.SenderID == userID {
+		return nil, ErrReadOwn
+	}
+
+	messages, err := s.advanceReadCursor(ctx, message.ChatID, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return message, nil
+	}
+
+	for _, read := range messages {
+		s.publishRead(ctx, read)
+	}
+
+	return &messages[len(messages)-1], nil
+}
+
+// MarkDelivered acknowledges that the given messages reached the user's device.
+// Messages are grouped per chat and each chat's delivery cursor moves to the
+// newest of them; the messages that became delivered are returned.
+func (s *ChatService) MarkDelivered(ctx context.Context, messageIDs []uuid.UUID, userID uuid.UUID) ([]domain.ChatMessage, error) {
+	latest := make(map[uuid.UUID]*domain.ChatMessage)
+	var chatOrder []uuid.UUID
+	for _, messageID := range messageIDs {
+		message, err := s.messages.GetByID(ctx, messageID)
+		if err != nil {
+			return nil, err
+		}
+		if message.SenderID == userID {
+			continue
+		}
+
+		current, ok := latest[message.ChatID]
+		if !ok {
+			if _, err := s.ensureParticipant(ctx, message.ChatID, userID); err != nil {
+				return nil, err
+			}
+			chatOrder = append(chatOrder, message.ChatID)
+		}
+		if !ok || isAfter(*message, *current) {
+			latest[message.ChatID] = message
+		}
+	}
+
+	var delivered []domain.ChatMessage
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		for _, chatID := range chatOrder {
+			messages, err := s.markDeliveredUpTo(ctx, chatID, userID, latest[chatID].ID)
+			if err != nil {
+				return err
+			}
+			delivered = append(delivered, messages...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return delivered, nil
+}
+
+func (s *ChatService) UnreadCount(ctx context.Context, chatID, userID uuid.UUID) (int, error) {
+	return s.messages.CountUnread(ctx, chatID, userID)
+}
+
+// advanceReadCursor moves the user's read cursor up to messageID and returns
+// the messages that became read as a result, oldest first.
+func (s *ChatService) advanceReadCursor(ctx context.Context, chatID, userID, messageID uuid.UUID) ([]domain.ChatMessage, error) {
+	var messages []domain.ChatMessage
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		messages, err = s.advanceReadCursorTx(ctx, chatID, userID, messageID)
+		return err
+	})
+	return messages, err
+}
+
+func (s *ChatService) advanceReadCursorTx(ctx context.Context, chatID, userID, messageID uuid.UUID) ([]domain.ChatMessage, error) {
+	var previousID *uuid.UUID
+	previous, err := s.readCursors.Get(ctx, chatID, userID)
+	if err == nil {
+		previousID = &previous.LastReadMessageID
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	advanced, err := s.readCursors.Advance(ctx, &domain.ReadCursor{
+		ChatID:            chatID,
+		UserID:            userID,
+		LastReadMessageID: messageID,
+		ReadAt:            time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !advanced {
+		return nil, nil
+	}
+
+	// A message that has been read has necessarily been delivered.
+	if _, err := s.markDeliveredUpTo(ctx, chatID, userID, messageID); err != nil {
+		return nil, err
+	}
+
+	return s.messages.ListReceivedBetween(ctx, chatID, userID, previousID, messageID)
+}
+
+// markDeliveredUpTo moves the user's delivery cursor up to messageID and
+// publishes the messages that became delivered as a result.
+func (s *ChatService) markDeliveredUpTo(ctx context.Context, chatID, userID, messageID uuid.UUID) ([]domain.ChatMessage, error) {
+	var previousID *uuid.UUID
+	previous, err := s.deliveries.Get(ctx, chatID, userID)
+	if err == nil {
+		previousID = &previous.LastDeliveredMessageID
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	advanced, err := s.deliveries.Advance(ctx, &domain.DeliveryCursor{
+		ChatID:                 chatID,
+		UserID:                 userID,
+		LastDeliveredMessageID: messageID,
+		DeliveredAt:            time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !advanced {
+		return nil, nil
+	}
+
+	messages, err := s.messages.ListReceivedBetween(ctx, chatID, userID, previousID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, message := range messages {
+		s.publishDelivered(ctx, message)
+	}
+
+	return messages, nil
+}
+
+func (s *ChatService) SubscribeMessages(ctx context.Context, chatID, userID uuid.UUID) (<-chan domain.ChatMessage, error) {
+	if _, err := s.ensureParticipant(ctx, chatID, userID); err != nil {
+		return nil, err
+	}
+	return subscribe(ctx, &s.mu, s.messageSubs, chatID, userID), nil
+}
+
+func (s *ChatService) SubscribeReads(ctx context.Context, chatID, userID uuid.UUID) (<-chan domain.ChatMessage, error) {
+	if _, err := s.ensureParticipant(ctx, chatID, userID); err != nil {
+		return nil, err
+	}
+	return subscribe(ctx, &s.mu, s.messageReadSubs, chatID, userID), nil
+}
+
+func (s *ChatService) SubscribeUpdates(ctx context.Context, chatID, userID uuid.UUID) (<-chan domain.ChatMessage, error) {
+	if _, err := s.ensureParticipant(ctx, chatID, userID); err != nil {
+		return nil, err
+	}
+	return subscribe(ctx, &s.mu, s.messageUpdateSubs, chatID, userID), nil
+}
+
+func (s *ChatService) SubscribeDeliveries(ctx context.Context, chatID, userID uuid.UUID) (<-chan domain.ChatMessage, error) {
+	if _, err := s.ensureParticipant(ctx, chatID, userID); err != nil {
+		return nil, err
+	}
+	return subscribe(ctx, &s.mu, s.deliverySubs, chatID, userID), nil
+}
+
+func (s *ChatService) SubscribeReactions(ctx context.Context, chatID, userID uuid.UUID) (<-chan domain.ReactionEvent, error) {
+	if _, err := s.ensureParticipant(ctx, chatID, userID); err != nil {
+		return nil, err
+	}
+	return subscribe(ctx, &s.mu, s.reactionSubs, chatID, userID), nil
+}
+
+// IsWatching reports whether the user has a live chatMessageAdded
+// subscription on the chat in this process.
+func (s *ChatService) IsWatching(chatID, userID uuid.UUID) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, sub := range s.messageSubs.topics[chatID] {
+		if sub.userID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// publishMessage fans the message out to live subscribers and records it as
+// delivered for every recipient whose subscription accepted it.
+func (s *ChatService) publishMessage(ctx context.Context, message domain.ChatMessage) {
+	s.tx.AfterCommit(ctx, func() {
+		s.deliverMessage(ctx, message)
+	})
+}
+
+// deliverMessage pushes a new message to subscribers and marks it delivered
+// for every recipient that received it.
+func (s *ChatService) deliverMessage(ctx context.Context, message domain.ChatMessage) {
+	recipients := publish(&s.mu, s.messageSubs, message.ChatID, message)
+
+	seen := make(map[uuid.UUID]struct{}, len(recipients))
+	for _, userID := range recipients {
+		if userID == message.SenderID {
+			continue
+		}
+		if _, ok := seen[userID]; ok {
+			continue
+		}
+		seen[userID] = struct{}{}
+
+		go func(userID uuid.UUID) {
+			deliverCtx := context.WithoutCancel(ctx)
+			if _, err := s.markDeliveredUpTo(deliverCtx, message.ChatID, userID, message.ID); err != nil {
+				logger.FromContext(deliverCtx).Warn(
+					"chat message delivery mark failed",
+					zap.String("message_id", message.ID.String()),
+					zap.String("user_id", userID.String()),
+					zap.Error(err),
+				)
+			}
+		}(userID)
+	}
+}
+
+func (s *ChatService) publishRead(ctx context.Context, message domain.ChatMessage) {
+	s.tx.AfterCommit(ctx, func() {
+		publish(&s.mu, s.messageReadSubs, message.ChatID, message)
+	})
+}
+
+// dropSubscriber ends the user's live subscriptions on a chat they no longer
+// belong to.
+func (s *ChatService) dropSubscriber(chatID, userID uuid.UUID) {
+	unsubscribeUser(&s.mu, s.messageSubs, chatID, userID)
+	unsubscribeUser(&s.mu, s.messageReadSubs, chatID, userID)
+	unsubscribeUser(&s.mu, s.messageUpdateSubs, chatID, userID)
+	unsubscribeUser(&s.mu, s.deliverySubs, chatID, userID)
+	unsubscribeUser(&s.mu, s.reactionSubs, chatID, userID)
+}
+
+func (s *ChatService) publishDelivered(ctx context.Context, message domain.ChatMessage) {
+	s.tx.AfterCommit(ctx, func() {
+		publish(&s.mu, s.deliverySubs, message.ChatID, message)
+	})
+}
+
+func (s *ChatService) publishUpdate(ctx context.Context, message domain.ChatMessage) {
+	s.tx.AfterCommit(ctx, func() {
+		publish(&s.mu, s.messageUpdateSubs, message.ChatID, message)
+	})
+}
+
+func (s *ChatService) publishReaction(ctx context.Context, event domain.ReactionEvent) {
+	s.tx.AfterCommit(ctx, func() {
+		publish(&s.mu, s.reactionSubs, event.ChatID, event)
+	})
+}
+
+func subscribe[T any](ctx context.Context, mu *sync.RWMutex, subs subscriptions[T], topic, userID uuid.UUID) <-chan T {
+	ch := make(chan T, 1)
+	ctx, cancel := context.WithCancel(ctx)
+
+	mu.Lock()
+	if subs.topics[topic] == nil {
+		subs.topics[topic] = make(map[chan T]subscriber)
+	}
+	subs.topics[topic][ch] = subscriber{userID: userID, cancel: cancel}
+	mu.Unlock()
+
+	active := metrics.SubscriptionsActive.WithLabelValues(subs.kind)
+	active.Inc()
+
+	go func() {
+		<-ctx.Done()
+		mu.Lock()
+		delete(subs.topics[topic], ch)
+		if len(subs.topics[topic]) == 0 {
+			delete(subs.topics, topic)
+		}
+		mu.Unlock()
+		close(ch)
+		active.Dec()
+	}()
+
+	return ch
+}
+
+// publish hands the event to every subscriber of the topic without blocking
+// and returns the users whose channels accepted it.
+func publish[T any](mu *sync.RWMutex, subs subscriptions[T], topic uuid.UUID, event T) []uuid.UUID {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var recipients []uuid.UUID
+	for ch, sub := range subs.topics[topic] {
+		select {
+		case ch <- event:
+			recipients = append(recipients, sub.userID)
+		default:
+			metrics.SubscriptionEventsDropped.WithLabelValues(subs.kind).Inc()
+		}
+	}
+	return recipients
+}
+
+// unsubscribeUser ends every subscription the user holds on the topic.
+func unsubscribeUser[T any](mu *sync.RWMutex, subs subscriptions[T], topic, userID uuid.UUID) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, sub := range subs.topics[topic] {
+		if sub.userID == userID {
+			sub.cancel()
+		}
+	}
+}
+
+func isAfter(a, b domain.ChatMessage) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return bytes.Compare(a.ID[:], b.ID[:]) > 0
+}
+
+func normalizeReaction(emoji string) (string, error) {
+	clean := strings.TrimSpace(emoji)
+	if clean == "" || len(clean) > maxReactionBytes || strings.ContainsAny(clean, " \t\n") {
+		return "", ErrInvalidReaction
+	}
+	return clean, nil
+}
+
+func (s *ChatService) ensureParticipant(ctx context.Context, chatID, userID uuid.UUID) (*domain.Chat, error) {
+	chat, err := s.chats.GetByID(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.participants.Get(ctx, chatID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrChatForbidden
+		}
+		return nil, err
+	}
+	return chat, nil
+}
+
+// checkContent runs the spam heuristics against a new message's text.
+func (s *ChatService) checkContent(ctx context.Context, chatID, senderID uuid.UUID, text string) (spam.Verdict, error) {
+	duplicates := 0
+	if s.spamPolicy.DuplicateChats > 0 && spam.DuplicateCandidate(text) {
+		since := time.Now().UTC().Add(-s.spamPolicy.DuplicateWindow)
+		count, err := s.messages.CountRecentDuplicates(ctx, senderID, chatID, text, since)
+		if err != nil {
+			return spam.Verdict{}, err
+		}
+		duplicates = count
+	}
+	return s.spamPolicy.Check(text, duplicates), nil
+}
+
+var moderationSeverity = map[domain.ModerationStatus]int{
+	domain.ModerationStatusClean:   0,
+	domain.ModerationStatusFlagged: 1,
+	domain.ModerationStatusHeld:    2,
+}
+
+func stricterStatus(a, b domain.ModerationStatus) domain.ModerationStatus {
+	if moderationSeverity[b] > moderationSeverity[a] {
+		return b
+	}
+	return a
+}
+
+func applyVerdict(message *domain.ChatMessage, verdict spam.Verdict) {
+	switch verdict.Action {
+	case spam.ActionHold:
+		message.ModerationStatus = domain.ModerationStatusHeld
+	case spam.ActionFlag:
+		message.ModerationStatus = domain.ModerationStatusFlagged
+	default:
+		message.ModerationStatus = domain.ModerationStatusClean
+	}
+
+	for _, reason := range verdict.Reasons {
+		message.ModerationReasons = append(message.ModerationReasons, string(reason))
+	}
+}
+
+func (s *ChatService) publishMessageSent(ctx context.Context, message *domain.ChatMessage) error {
+	return s.events.Publish(ctx, events.TypeChatMessageSent, events.ChatMessageSent{
+		MessageID: message.ID,
+		ChatID:    message.ChatID,
+		SenderID:  message.SenderID,
+		Kind:      string(message.Kind),
+	})
+}
+
+// removeFiles deletes each distinct path once. Legacy messages list their
+// photo both as photo_path and as an attachment.
+func (s *ChatService) removeFiles(ctx context.Context, messageID uuid.UUID, paths []string) {
+	seen := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		seen[path] = struct{}{}
+		if err := s.storage.Remove(ctx, path); err != nil {
+			logger.FromContext(ctx).Warn("chat file remove failed", zap.String("message_id", messageID.String()), zap.Error(err))
+		}
+	}
+}
+
+func attachmentPaths(attachments []domain.MessageAttachment) []string {
+	paths := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		paths = append(paths, attachment.Path)
+	}
+	return paths
+}
+
+func participantEvent(event domain.SystemEvent, userID uuid.UUID) domain.SystemPayload {
+	return domain.SystemPayload{
+		Event: event,
+		Data:  map[string]any{"user_id": userID.String()},
+	}
+}
+
+func (s *ChatService) ensureNotBlocked(ctx context.Context, userID uuid.UUID, otherIDs []uuid.UUID) error {
+	blocked, err := s.blocks.ExistsBetween(ctx, userID, otherIDs)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrUserBlocked
+	}
+	return nil
+}
+
+// ensureCanJoin keeps a new member apart from anyone already in the chat,
+// not just the owner, when either has blocked the other.
+func (s *ChatService) ensureCanJoin(ctx context.Context, chatID, userID uuid.UUID) error {
+	members, err := s.participants.ListByChat(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	memberIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		memberIDs = append(memberIDs, member.UserID)
+	}
+	return s.ensureNotBlocked(ctx, userID, memberIDs)
+}
+
+// ensureSenderNotBlocked stops a user from posting in any chat, direct or
+// group, that includes someone who has blocked them. The blocker can still
+// post, so one block does not silence them in shared groups.
+func (s *ChatService) ensureSenderNotBlocked(ctx context.Context, chatID, senderID uuid.UUID) error {
+	participants, err := s.participants.ListByChat(ctx, chatID)
+	if err != nil {
+		return err
+	}
+
+	others := make([]uuid.UUID, 0, len(participants))
+	for _, participant := range participants {
+		if participant.UserID != senderID {
+			others = append(others, participant.UserID)
+		}
+	}
+	blocked, err := s.blocks.BlockedByAny(ctx, senderID, others)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrUserBlocked
+	}
+	return nil
+}
+
+func (s *ChatService) ensureOwner(ctx context.Context, chatID, userID uuid.UUID) error {
+	if _, err := s.chats.GetByID(ctx, chatID); err != nil {
+		return err
+	}
+	participant, err := s.participants.Get(ctx, chatID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrChatForbidden
+		}
+		return err
+	}
+	if participant.Role != domain.ParticipantRoleOwner {
+		return ErrNotChatOwner
+	}
+	return nil
 }
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = apperr.New(apperr.CodeValidation, "invalid cursor")
+
+// EncodeMessageCursor turns a timeline position into the opaque cursor handed
+// to clients.
+func EncodeMessageCursor(cursor domain.MessageCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeMessageCursor(value string) (domain.MessageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return domain.MessageCursor{}, ErrInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return domain.MessageCursor{}, ErrInvalidCursor
+	}
+
+	parsedAt, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return domain.MessageCursor{}, ErrInvalidCursor
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return domain.MessageCursor{}, ErrInvalidCursor
+	}
+
+	return domain.MessageCursor{CreatedAt: parsedAt, ID: parsedID}, nil
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/logger"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrBlockSelf     = apperr.New(apperr.CodeValidation, "cannot block yourself")
+	ErrInvalidReason = apperr.New(apperr.CodeValidation, "report reason must be 1-1000 characters")
+	ErrReportOwn     = apperr.New(apperr.CodeValidation, "cannot report your own message")
+)
+
+const (
+	maxReportReasonLength = 1000
+	reportSnapshotLimit   = 50
+	maxHeldMessages       = 100
+)
+
+type ModerationService struct {
+	blocks       repository.BlockRepository
+	reports      repository.ReportRepository
+	users        repository.UserRepository
+	chats        repository.ChatRepository
+	participants repository.ParticipantRepository
+	messages     repository.MessageRepository
+	attachments  repository.AttachmentRepository
+	chat         *ChatService
+	admins       []uuid.UUID
+}
+
+func NewModerationService(
+	blocks repository.BlockRepository,
+	reports repository.ReportRepository,
+	users repository.UserRepository,
+	chats repository.ChatRepository,
+	participants repository.ParticipantRepository,
+	messages repository.MessageRepository,
+	attachments repository.AttachmentRepository,
+	chat *ChatService,
+	admins []uuid.UUID,
+) *ModerationService {
+	return &ModerationService{
+		blocks:       blocks,
+		reports:      reports,
+		users:        users,
+		chats:        chats,
+		participants: participants,
+		messages:     messages,
+		attachments:  attachments,
+		chat:         chat,
+		admins:       admins,
+	}
+}
+
+func (s *ModerationService) BlockUser(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	if blockerID == blockedID {
+		return ErrBlockSelf
+	}
+
+	if _, err := s.users.GetByID(ctx, blockedID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	block := &domain.UserBlock{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.blocks.Create(ctx, block); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info(
+		"user blocked",
+		zap.String("blocker_id", blockerID.String()),
+		zap.String("blocked_id", blockedID.String()),
+	)
+	return nil
+}
+
+func (s *ModerationService) UnblockUser(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	removed, err := s.blocks.Delete(ctx, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+
+	if removed {
+		logger.FromContext(ctx).Info(
+			"user unblocked",
+			zap.String("blocker_id", blockerID.String()),
+			zap.String("blocked_id", blockedID.String()),
+		)
+	}
+	return nil
+}
+
+// ReportChat files a report against a whole chat, keeping a copy of its most
+// recent messages.
+func (s *ModerationService) ReportChat(ctx context.Context, chatID, reporterID uuid.UUID, reason string) (*domain.ModerationReport, error) {
+	cleanReason, err := normalizeReason(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	chat, err := s.ensureParticipant(ctx, chatID, reporterID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := s.messages.ListLatestByChat(ctx, chatID, reportSnapshotLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.snapshot(ctx, chat, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	var reportedUserID *uuid.UUID
+	if chat.CreatorID == reporterID {
+		reportedUserID = &chat.InitiatorID
+	} else if chat.InitiatorID == reporterID {
+		reportedUserID = &chat.CreatorID
+	}
+
+	return s.createReport(ctx, &domain.ModerationReport{
+		ReporterID:     reporterID,
+		ChatID:         &chat.ID,
+		ReportedUserID: reportedUserID,
+		Reason:         cleanReason,
+		Snapshot:       snapshot,
+	})
+}
+
+// ReportMessage files a report against a single message sent by someone else.
+func (s *ModerationService) ReportMessage(ctx context.Context, messageID, reporterID uuid.UUID, reason string) (*domain.ModerationReport, error) {
+	cleanReason, err := normalizeReason(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// GetMessage checks membership and hides other users' held messages, so
+	// their text cannot leak into a snapshot.
+	message, err := s.chat.GetMessage(ctx, messageID, reporterID)
+	if err != nil {
+		return nil, err
+	}
+	if message.SenderID == reporterID {
+		return nil, ErrReportOwn
+	}
+
+	chat, err := s.chats.GetByID(ctx, message.ChatID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.snapshot(ctx, chat, []domain.ChatMessage{*message})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createReport(ctx, &domain.ModerationReport{
+		ReporterID:     reporterID,
+		ChatID:         &chat.ID,
+		MessageID:      &message.ID,
+		ReportedUserID: &message.SenderID,
+		Reason:         cleanReason,
+		Snapshot:       snapshot,
+	})
+}
+
+func (s *ModerationService) createReport(ctx context.Context, report *domain.ModerationReport) (*domain.ModerationReport, error) {
+	report.ID = uuid.New()
+	report.Status = domain.ReportStatusPending
+	report.CreatedAt = time.Now().UTC()
+
+	if err := s.reports.Create(ctx, report); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info(
+		"moderation report created",
+		zap.String("report_id", report.ID.String()),
+		zap.String("reporter_id", report.ReporterID.String()),
+	)
+	return report, nil
+}
+
+func (s *ModerationService) snapshot(ctx context.Context, chat *domain.Chat, messages []domain.ChatMessage) (domain.ReportSnapshot, error) {
+	snapshot := domain.ReportSnapshot{
+		ChatID:    chat.ID,
+		RequestID: chat.RequestID,
+		Messages:  make([]domain.ReportedMessage, 0, len(messages)),
+	}
+
+	for _, message := range messages {
+		attachments, err := s.attachments.ListByMessage(ctx, message.ID)
+		if err != nil {
+			return domain.ReportSnapshot{}, err
+		}
+
+		paths := make([]string, 0, len(attachments))
+		for _, attachment := range attachments {
+			paths = append(paths, attachment.Path)
+		}
+
+		snapshot.Messages = append(snapshot.Messages, domain.ReportedMessage{
+			ID:          message.ID,
+			SenderID:    message.SenderID,
+			Text:        message.Text,
+			Attachments: paths,
+			CreatedAt:   message.CreatedAt,
+			EditedAt:    message.EditedAt,
+			DeletedAt:   message.DeletedAt,
+		})
+	}
+
+	return snapshot, nil
+}
+
+// HeldMessages lists messages the spam checks held back, oldest first.
+// Only admins may review them.
+func (s *ModerationService) HeldMessages(ctx context.Context, actorID uuid.UUID, limit int32) ([]domain.ChatMessage, error) {
+	if err := s.ensureAdmin(actorID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > maxHeldMessages {
+		limit = maxHeldMessages
+	}
+	return s.chat.ListHeldMessages(ctx, limit)
+}
+
+func (s *ModerationService) ReleaseMessage(ctx context.Context, actorID, messageID uuid.UUID) (*domain.ChatMessage, error) {
+	if err := s.ensureAdmin(actorID); err != nil {
+		return nil, err
+	}
+	return s.chat.ReleaseHeldMessage(ctx, messageID)
+}
+
+func (s *ModerationService) RejectMessage(ctx context.Context, actorID, messageID uuid.UUID) error {
+	if err := s.ensureAdmin(actorID); err != nil {
+		return err
+	}
+	return s.chat.RejectHeldMessage(ctx, messageID)
+}
+
+func (s *ModerationService) ensureAdmin(userID uuid.UUID) error {
+	if !slices.Contains(s.admins, userID) {
+		return ErrAdminOnly
+	}
+	return nil
+}
+
+func (s *ModerationService) ensureParticipant(ctx context.Context, chatID, userID uuid.UUID) (*domain.Chat, error) {
+	chat, err := s.chats.GetByID(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.participants.Get(ctx, chatID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrChatForbidden
+		}
+		return nil, err
+	}
+	return chat, nil
+}
+
+func normalizeReason(reason string) (string, error) {
+	clean := strings.TrimSpace(reason)
+	if clean == "" || utf8.RuneCountInString(clean) > maxReportReasonLength {
+		return "", ErrInvalidReason
+	}
+	return clean, nil
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/barzurustami/bozor/internal/validate"
+	"github.com/google/uuid"
+)
+
+// NotificationService keeps each user's notification center and delivery
+// preferences, and streams new notifications to their live subscriptions.
+type NotificationService struct {
+	tx            repository.Transactor
+	notifications repository.NotificationRepository
+	settings      repository.NotificationSettingsRepository
+
+	mu   sync.RWMutex
+	subs subscriptions[domain.Notification]
+}
+
+// NotificationSettingsUpdate changes the settings that are set and keeps
+// the rest. Preferences not listed keep their value.
+type NotificationSettingsUpdate struct {
+	PushPreviews *bool
+	Preferences  []domain.NotificationPreference
+	QuietHours   *QuietHoursUpdate
+}
+
+// QuietHoursUpdate gives Start and End as local HH:MM and TimeZone as an
+// IANA name such as Asia/Tashkent.
+type QuietHoursUpdate struct {
+	Enabled  bool
+	Start    string
+	End      string
+	TimeZone string
+}
+
+func NewNotificationService(
+	tx repository.Transactor,
+	notifications repository.NotificationRepository,
+	settings repository.NotificationSettingsRepository,
+) *NotificationService {
+	return &NotificationService{
+		tx:            tx,
+		notifications: notifications,
+		settings:      settings,
+		subs:          newSubscriptions[domain.Notification]("notificationAdded"),
+	}
+}
+
+// Notify records the notification and, unless the recipient turned in-app
+// notifications of its type off, publishes it to them. It reports false
+// when a notification was already recorded for the same event, so callers
+// can skip side effects they performed the first time.
+func (s *NotificationService) Notify(ctx context.Context, notification domain.Notification) (bool, error) {
+	settings, err := s.Settings(ctx, notification.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	notification.ID = uuid.New()
+	notification.CreatedAt = time.Now().UTC()
+	notification.InApp = settings.Allows(domain.NotificationChannelInApp, notification.Type, notification.CreatedAt)
+
+	created, err := s.notifications.Create(ctx, &notification)
+	if err != nil || !created {
+		return false, err
+	}
+
+	if notification.InApp {
+		publish(&s.mu, s.subs, notification.UserID, notification)
+	}
+	return true, nil
+}
+
+// List returns up to limit notifications, newest first, and whether more
+// follow.
+func (s *NotificationService) List(ctx context.Context, userID uuid.UUID, after *domain.MessageCursor, limit int32) ([]domain.Notification, bool, error) {
+	notifications, err := s.notifications.ListByUser(ctx, userID, after, limit+1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(notifications) > int(limit)
+	if hasMore {
+		notifications = notifications[:limit]
+	}
+	return notifications, hasMore, nil
+}
+
+// MarkRead marks the given notifications read, or every notification when
+// ids is nil, and returns how many remain unread.
+func (s *NotificationService) MarkRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error) {
+	if ids != nil && len(ids) == 0 {
+		return s.UnreadCount(ctx, userID)
+	}
+	if _, err := s.notifications.MarkRead(ctx, userID, ids, time.Now().UTC()); err != nil {
+		return 0, err
+	}
+	return s.UnreadCount(ctx, userID)
+}
+
+func (s *NotificationService) UnreadCount(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.notifications.CountUnread(ctx, userID)
+}
+
+func (s *NotificationService) Subscribe(ctx context.Context, userID uuid.UUID) <-chan domain.Notification {
+	return subscribe(ctx, &s.mu, s.subs, userID, userID)
+}
+
+func (s *NotificationService) Settings(ctx context.Context, userID uuid.UUID) (domain.NotificationSettings, error) {
+	settings := domain.DefaultNotificationSettings(userID)
+	stored, err := s.settings.Get(ctx, userID)
+	switch {
+	case err == nil:
+		settings = *stored
+	case !errors.Is(err, repository.ErrNotFound):
+		return domain.NotificationSettings{}, err
+	}
+
+	settings.Preferences, err = s.settings.ListPreferences(ctx, userID)
+	if err != nil {
+		return domain.NotificationSettings{}, err
+	}
+	return settings, nil
+}
+
+func (s *NotificationService) UpdateSettings(ctx context.Context, userID uuid.UUID, update NotificationSettingsUpdate) (domain.NotificationSettings, error) {
+	v := validate.New()
+	for i, pref := range update.Preferences {
+		field := fmt.Sprintf("preferences[%d]", i)
+		v.Check(slices.Contains(domain.NotificationChannels, pref.Channel), field+".channel", "is not a notification channel")
+		v.Check(slices.Contains(domain.NotificationTypes, pref.Type), field+".type", "is not a notification type")
+	}
+
+	var quietHours domain.QuietHours
+	if update.QuietHours != nil {
+		start, startOK := parseClock(update.QuietHours.Start)
+		end, endOK := parseClock(update.QuietHours.End)
+		_, zoneErr := time.LoadLocation(update.QuietHours.TimeZone)
+		v.Check(startOK, "quietHours.start", "must be a time as HH:MM")
+		v.Check(endOK, "quietHours.end", "must be a time as HH:MM")
+		v.Check(update.QuietHours.TimeZone != "" && zoneErr == nil, "quietHours.timeZone", "must be an IANA time zone")
+		quietHours = domain.QuietHours{
+			Enabled:  update.QuietHours.Enabled,
+			Start:    start,
+			End:      end,
+			TimeZone: update.QuietHours.TimeZone,
+		}
+	}
+	if err := v.Err(); err != nil {
+		return domain.NotificationSettings{}, err
+	}
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		settings, err := s.Settings(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if update.PushPreviews != nil {
+			settings.PushPreviews = *update.PushPreviews
+		}
+		if update.QuietHours != nil {
+			settings.QuietHours = quietHours
+		}
+		settings.UpdatedAt = time.Now().UTC()
+
+		if err := s.settings.Upsert(ctx, &settings); err != nil {
+			return err
+		}
+		for _, pref := range update.Preferences {
+			if err := s.settings.UpsertPreference(ctx, userID, pref); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.NotificationSettings{}, err
+	}
+
+	return s.Settings(ctx, userID)
+}
+
+// parseClock parses HH:MM into minutes after midnight.
+func parseClock(value string) (int, bool) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, false
+	}
+	return parsed.Hour()*60 + parsed.Minute(), true
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/events"
+	"github.com/barzurustami/bozor/internal/logger"
+	"github.com/barzurustami/bozor/internal/push"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/barzurustami/bozor/internal/sms"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	pushFallbackTitle = "New message"
+	pushPreviewLength = 100
+)
+
+// NotificationHandler turns domain events into notifications. A chat opened
+// on someone's request is their new offer; every visible user message
+// notifies the other participants, and is pushed or texted to those who are
+// not watching the chat.
+type NotificationHandler struct {
+	notifications *NotificationService
+	chats         *ChatService
+	push          *PushService
+	sms           sms.Sender
+	users         repository.UserRepository
+	participants  repository.ParticipantRepository
+	messages      repository.MessageRepository
+	chatSettings  repository.ChatSettingsRepository
+	profiles      repository.ProfileRepository
+}
+
+func NewNotificationHandler(
+	notifications *NotificationService,
+	chats *ChatService,
+	push *PushService,
+	smsSender sms.Sender,
+	users repository.UserRepository,
+	participants repository.ParticipantRepository,
+	messages repository.MessageRepository,
+	chatSettings repository.ChatSettingsRepository,
+	profiles repository.ProfileRepository,
+) *NotificationHandler {
+	return &NotificationHandler{
+		notifications: notifications,
+		chats:         chats,
+		push:          push,
+		sms:           smsSender,
+		users:         users,
+		participants:  participants,
+		messages:      messages,
+		chatSettings:  chatSettings,
+		profiles:      profiles,
+	}
+}
+
+func (h *NotificationHandler) Name() string {
+	return "notifications"
+}
+
+func (h *NotificationHandler) Handle(ctx context.Context, event events.Event) error {
+	switch event.Type {
+	case events.TypeChatCreated:
+		return h.chatCreated(ctx, event)
+	case events.TypeChatMessageSent:
+		return h.messageSent(ctx, event)
+	}
+	return nil
+}
+
+func (h *NotificationHandler) chatCreated(ctx context.Context, event events.Event) error {
+	var payload events.ChatCreated
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+
+	_, err := h.notifications.Notify(ctx, domain.Notification{
+		UserID:    payload.CreatorID,
+		Type:      domain.NotificationTypeNewOffer,
+		ActorID:   &payload.InitiatorID,
+		RequestID: &payload.RequestID,
+		ChatID:    &payload.ChatID,
+		EventID:   event.ID,
+	})
+	return err
+}
+
+func (h *NotificationHandler) messageSent(ctx context.Context, event events.Event) error {
+	var payload events.ChatMessageSent
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+	if payload.Kind == string(domain.MessageKindSystem) {
+		return nil
+	}
+
+	// The message may have been held or deleted by the time the event is
+	// dispatched; neither should reach the other participants.
+	message, err := h.messages.GetByID(ctx, payload.MessageID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if message.DeletedAt != nil || message.ModerationStatus == domain.ModerationStatusHeld {
+		return nil
+	}
+
+	participants, err := h.participants.ListByChat(ctx, payload.ChatID)
+	if err != nil {
+		return err
+	}
+
+	senderName := h.senderName(ctx, payload.SenderID)
+	for _, participant := range participants {
+		if participant.UserID == payload.SenderID {
+			continue
+		}
+		created, err := h.notifications.Notify(ctx, domain.Notification{
+			UserID:    participant.UserID,
+			Type:      domain.NotificationTypeNewMessage,
+			ActorID:   &payload.SenderID,
+			ChatID:    &payload.ChatID,
+			MessageID: &payload.MessageID,
+			EventID:   event.ID,
+		})
+		if err != nil {
+			return err
+		}
+		// Alerting only alongside a new notification keeps redelivered
+		// events from pushing or texting twice.
+		if created {
+			h.alertMessage(ctx, participant.UserID, message, senderName)
+		}
+	}
+	return nil
+}
+
+// alertMessage pushes and texts message to a recipient who is not watching
+// the chat and has not muted it. Each channel also needs the recipient's
+// preference for new messages and stays silent in their quiet hours.
+// Failures are logged: the in-app notification already exists.
+func (h *NotificationHandler) alertMessage(ctx context.Context, userID uuid.UUID, message *domain.ChatMessage, senderName string) {
+	log := logger.FromContext(ctx).With(
+		zap.String("message_id", message.ID.String()),
+		zap.String("user_id", userID.String()),
+	)
+
+	if h.chats.IsWatching(message.ChatID, userID) {
+		return
+	}
+
+	chatSettings, err := h.chatSettings.Get(ctx, message.ChatID, userID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		log.Warn("alerts skipped: chat settings lookup failed", zap.Error(err))
+		return
+	}
+	if chatSettings != nil && chatSettings.Muted(time.Now()) {
+		return
+	}
+
+	settings, err := h.notifications.Settings(ctx, userID)
+	if err != nil {
+		log.Warn("alerts skipped: notification settings lookup failed", zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+	if settings.Allows(domain.NotificationChannelPush, domain.NotificationTypeNewMessage, now) {
+		h.pushMessage(ctx, log, userID, message, settings, senderName)
+	}
+	if settings.Allows(domain.NotificationChannelSMS, domain.NotificationTypeNewMessage, now) {
+		text := "You have a new message on Bozor."
+		if senderName != "" {
+			text = senderName + " sent you a message on Bozor."
+		}
+		if err := h.text(ctx, userID, text); err != nil {
+			log.Warn("sms send failed", zap.Error(err))
+		}
+	}
+}
+
+func (h *NotificationHandler) pushMessage(ctx context.Context, log *zap.Logger, userID uuid.UUID, message *domain.ChatMessage, settings domain.NotificationSettings, senderName string) {
+	title := senderName
+	if title == "" {
+		title = pushFallbackTitle
+	}
+	body := pushFallbackTitle
+	if settings.PushPreviews {
+		body = messagePreview(message)
+	}
+
+	err := h.push.SendToUser(ctx, userID, push.Message{
+		Title: title,
+		Body:  body,
+		Data: map[string]string{
+			"type":       string(domain.NotificationTypeNewMessage),
+			"chat_id":    message.ChatID.String(),
+			"message_id": message.ID.String(),
+		},
+	})
+	if err != nil {
+		log.Warn("push send failed", zap.Error(err))
+	}
+}
+
+// text sends an SMS to the user's sign-in phone number.
+func (h *NotificationHandler) text(ctx context.Context, userID uuid.UUID, text string) error {
+	user, err := h.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return h.sms.Send(ctx, user.Phone, text)
+}
+
+// senderName returns the sender's profile name, or "" when they have none.
+func (h *NotificationHandler) senderName(ctx context.Context, senderID uuid.UUID) string {
+	profile, err := h.profiles.GetByUserID(ctx, senderID)
+	if err != nil {
+		return ""
+	}
+	return profile.FullName
+}
+
+func messagePreview(message *domain.ChatMessage) string {
+	if message.Text == "" {
+		return "Sent an attachment"
+	}
+	if utf8.RuneCountInString(message.Text) <= pushPreviewLength {
+		return message.Text
+	}
+	return string([]rune(message.Text)[:pushPreviewLength-1]) + "…"
+}
@@ -2,51 +2,127 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/events"
 	"github.com/barzurustami/bozor/internal/logger"
 	"github.com/barzurustami/bozor/internal/repository"
 	"github.com/barzurustami/bozor/internal/storage"
+	"github.com/barzurustami/bozor/internal/validate"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 type PhotoService struct {
+	tx       repository.Transactor
 	storage  *storage.LocalStorage
 	photos   repository.PhotoRepository
 	requests repository.RequestRepository
+	chat     *ChatService
+	events   *events.Publisher
 }
 
-func NewPhotoService(storage *storage.LocalStorage, photos repository.PhotoRepository, requests repository.RequestRepository) *PhotoService {
-	return &PhotoService{storage: storage, photos: photos, requests: requests}
+func NewPhotoService(
+	tx repository.Transactor,
+	storage *storage.LocalStorage,
+	photos repository.PhotoRepository,
+	requests repository.RequestRepository,
+	chat *ChatService,
+	events *events.Publisher,
+) *PhotoService {
+	return &PhotoService{tx: tx, storage: storage, photos: photos, requests: requests, chat: chat, events: events}
 }
 
-func (s *PhotoService) Upload(ctx context.Context, requestID uuid.UUID, uploads []graphql.Upload) ([]domain.Photo, error) {
-	if _, err := s.requests.GetByID(ctx, requestID); err != nil {
+const maxRequestPhotos = 20
+
+var allowedPhotoTypes = []string{"image/jpeg", "image/png", "image/webp", "image/heic"}
+
+func (s *PhotoService) Upload(ctx context.Context, requestID, uploaderID uuid.UUID, uploads []graphql.Upload) ([]domain.Photo, error) {
+	request, err := s.requests.GetByID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	v := validate.New()
+	v.MaxItems("files", len(uploads), maxRequestPhotos)
+	if err := v.Err(); err != nil {
 		return nil, err
 	}
 
 	stored := make([]domain.Photo, 0, len(uploads))
-	for _, upload := range uploads {
-		path, err := s.storage.Save(ctx, upload)
+	for i, upload := range uploads {
+		file, err := s.storage.SaveFile(ctx, upload)
 		if err != nil {
+			s.removePhotos(ctx, stored)
+			return nil, err
+		}
+		v.ContentType(fmt.Sprintf("files[%d]", i), file.ContentType, allowedPhotoTypes)
+		if err := v.Err(); err != nil {
+			s.removePhotos(ctx, append(stored, domain.Photo{Path: file.Path}))
 			return nil, err
 		}
 
 		stored = append(stored, domain.Photo{
 			ID:        uuid.New(),
 			RequestID: requestID,
-			Path:      path,
+			Path:      file.Path,
 			CreatedAt: time.Now().UTC(),
 		})
 	}
 
-	if err := s.photos.CreateMany(ctx, stored); err != nil {
+	photoIDs := make([]uuid.UUID, 0, len(stored))
+	photoIDStrings := make([]string, 0, len(stored))
+	for _, photo := range stored {
+		photoIDs = append(photoIDs, photo.ID)
+		photoIDStrings = append(photoIDStrings, photo.ID.String())
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.photos.CreateMany(ctx, stored); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, events.TypeRequestPhotosAdded, events.RequestPhotosAdded{
+			RequestID:  requestID,
+			UploaderID: uploaderID,
+			PhotoIDs:   photoIDs,
+		})
+	})
+	if err != nil {
+		s.removePhotos(ctx, stored)
 		return nil, err
 	}
 
-	logger.FromContext(ctx).Info("photos uploaded", zap.String("request_id", requestID.String()), zap.Int("count", len(stored)))
+	log := logger.FromContext(ctx)
+	log.Info("photos uploaded", zap.String("request_id", requestID.String()), zap.Int("count", len(stored)))
+
+	// Only the request owner's photos are announced in its chats.
+	if uploaderID != request.CustomerID {
+		return stored, nil
+	}
+
+	payload := domain.SystemPayload{
+		Event: domain.SystemEventRequestPhotosAdded,
+		Data: map[string]any{
+			"request_id": requestID.String(),
+			"photo_ids":  photoIDStrings,
+		},
+	}
+	// The photos are already saved; a failed chat notice should not fail the upload.
+	if err := s.chat.PostRequestEvent(ctx, requestID, uploaderID, payload); err != nil {
+		log.Warn("chat photo event failed", zap.String("request_id", requestID.String()), zap.Error(err))
+	}
+
 	return stored, nil
 }
+
+// removePhotos deletes files stored for an upload that did not complete.
+func (s *PhotoService) removePhotos(ctx context.Context, photos []domain.Photo) {
+	for _, photo := range photos {
+		if err := s.storage.Remove(ctx, photo.Path); err != nil {
+			logger.FromContext(ctx).Warn("photo remove failed", zap.String("path", photo.Path), zap.Error(err))
+		}
+	}
+}
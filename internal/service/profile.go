@@ -2,11 +2,14 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/barzurustami/bozor/internal/domain"
 	"github.com/barzurustami/bozor/internal/logger"
 	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/barzurustami/bozor/internal/validate"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -19,10 +22,35 @@ func NewProfileService(profiles repository.ProfileRepository) *ProfileService {
 	return &ProfileService{profiles: profiles}
 }
 
+const (
+	maxFullNameLen = 100
+	maxAboutLen    = 2000
+	maxCityLen     = 100
+	maxSkills      = 30
+	maxSkillLen    = 50
+)
+
 func (s *ProfileService) Upsert(ctx context.Context, userID uuid.UUID, fullName, about, city string, skills []string) (*domain.Profile, error) {
-	if skills == nil {
-		skills = []string{}
+	fullName = strings.TrimSpace(fullName)
+	about = strings.TrimSpace(about)
+	city = strings.TrimSpace(city)
+
+	v := validate.New()
+	v.Length("fullName", fullName, 1, maxFullNameLen)
+	v.Length("about", about, 0, maxAboutLen)
+	v.Length("city", city, 0, maxCityLen)
+	v.MaxItems("skills", len(skills), maxSkills)
+
+	cleanSkills := make([]string, 0, len(skills))
+	for i, skill := range skills {
+		skill = strings.TrimSpace(skill)
+		v.Length(fmt.Sprintf("skills[%d]", i), skill, 1, maxSkillLen)
+		cleanSkills = append(cleanSkills, skill)
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
+	skills = cleanSkills
 
 	profile := &domain.Profile{
 		ID:        uuid.New(),
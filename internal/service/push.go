@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/logger"
+	"github.com/barzurustami/bozor/internal/push"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/barzurustami/bozor/internal/validate"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const maxDeviceTokenLength = 4096
+
+// PushService keeps users' device tokens and sends push notifications to
+// them.
+type PushService struct {
+	devices repository.DeviceRepository
+	sender  push.Sender
+}
+
+func NewPushService(devices repository.DeviceRepository, sender push.Sender) *PushService {
+	return &PushService{devices: devices, sender: sender}
+}
+
+func (s *PushService) RegisterDevice(ctx context.Context, userID uuid.UUID, platform domain.DevicePlatform, token string) (*domain.Device, error) {
+	v := validate.New()
+	v.Length("token", token, 1, maxDeviceTokenLength)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	device := &domain.Device{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Platform:  platform,
+		Token:     token,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.devices.Upsert(ctx, device); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// UnregisterDevice forgets the token, e.g. on sign-out. Unknown tokens are
+// ignored.
+func (s *PushService) UnregisterDevice(ctx context.Context, userID uuid.UUID, token string) error {
+	_, err := s.devices.Delete(ctx, userID, token)
+	return err
+}
+
+// SendToUser sends message to every device of the user. Tokens the provider
+// rejects as invalid are removed.
+func (s *PushService) SendToUser(ctx context.Context, userID uuid.UUID, message push.Message) error {
+	devices, err := s.devices.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, device := range devices {
+		message.Token = device.Token
+		err := s.sender.Send(ctx, message)
+		if errors.Is(err, push.ErrInvalidToken) {
+			logger.FromContext(ctx).Info("removing invalid push token", zap.String("device_id", device.ID.String()))
+			if err := s.devices.DeleteByToken(ctx, device.Token); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
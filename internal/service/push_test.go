@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/push"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// stubDevices is an in-memory DeviceRepository.
+type stubDevices struct {
+	mu      sync.Mutex
+	devices []domain.Device
+}
+
+func (s *stubDevices) Upsert(_ context.Context, device *domain.Device) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.devices = append(s.devices, *device)
+	return nil
+}
+
+func (s *stubDevices) ListByUser(_ context.Context, userID uuid.UUID) ([]domain.Device, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var devices []domain.Device
+	for _, device := range s.devices {
+		if device.UserID == userID {
+			devices = append(devices, device)
+		}
+	}
+	return devices, nil
+}
+
+func (s *stubDevices) Delete(_ context.Context, userID uuid.UUID, token string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, device := range s.devices {
+		if device.UserID == userID && device.Token == token {
+			s.devices = append(s.devices[:i], s.devices[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *stubDevices) DeleteByToken(_ context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, device := range s.devices {
+		if device.Token == token {
+			s.devices = append(s.devices[:i], s.devices[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+type gatewayRequest struct {
+	To           string `json:"to"`
+	Notification struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	} `json:"notification"`
+	Data map[string]string `json:"data"`
+}
+
+// pushGateway is an FCM-style gateway that accepts every token except the
+// unregistered ones.
+type pushGateway struct {
+	*httptest.Server
+	unregistered map[string]bool
+
+	mu       sync.Mutex
+	received []gatewayRequest
+}
+
+func newPushGateway(t *testing.T, unregistered ...string) *pushGateway {
+	g := &pushGateway{unregistered: make(map[string]bool)}
+	for _, token := range unregistered {
+		g.unregistered[token] = true
+	}
+	g.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req gatewayRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		g.mu.Lock()
+		g.received = append(g.received, req)
+		g.mu.Unlock()
+
+		if g.unregistered[req.To] {
+			_, _ = w.Write([]byte(`{"failure":1,"results":[{"error":"NotRegistered"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"failure":0,"results":[{"message_id":"1"}]}`))
+	}))
+	t.Cleanup(g.Close)
+	return g
+}
+
+func (g *pushGateway) requests() []gatewayRequest {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]gatewayRequest(nil), g.received...)
+}
+
+func newTestPushService(t *testing.T, gateway *pushGateway, userID uuid.UUID, tokens ...string) (*PushService, *stubDevices) {
+	t.Helper()
+	devices := &stubDevices{}
+	svc := NewPushService(devices, push.NewFCMSender(gateway.URL, "secret", time.Second))
+	for _, token := range tokens {
+		if _, err := svc.RegisterDevice(context.Background(), userID, domain.DevicePlatformAndroid, token); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return svc, devices
+}
+
+func TestSendToUser(t *testing.T) {
+	gateway := newPushGateway(t)
+	userID := uuid.New()
+	svc, devices := newTestPushService(t, gateway, userID, "phone", "tablet")
+
+	err := svc.SendToUser(context.Background(), userID, push.Message{Title: "Aziz", Body: "Hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := gateway.requests()
+	if len(got) != 2 {
+		t.Fatalf("gateway got %d requests, want 2", len(got))
+	}
+	for i, token := range []string{"phone", "tablet"} {
+		if got[i].To != token || got[i].Notification.Title != "Aziz" || got[i].Notification.Body != "Hello" {
+			t.Errorf("request %d = %+v, want Aziz/Hello to %s", i, got[i], token)
+		}
+	}
+	if left, _ := devices.ListByUser(context.Background(), userID); len(left) != 2 {
+		t.Errorf("%d devices left, want 2", len(left))
+	}
+}
+
+func TestSendToUserRemovesUnregisteredToken(t *testing.T) {
+	gateway := newPushGateway(t, "stale")
+	userID := uuid.New()
+	svc, devices := newTestPushService(t, gateway, userID, "stale", "phone")
+
+	if err := svc.SendToUser(context.Background(), userID, push.Message{Title: "Aziz", Body: "Hello"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(gateway.requests()); got != 2 {
+		t.Errorf("gateway got %d requests, want 2", got)
+	}
+	left, _ := devices.ListByUser(context.Background(), userID)
+	if len(left) != 1 || left[0].Token != "phone" {
+		t.Errorf("devices left = %+v, want only phone", left)
+	}
+}
+
+func TestPushMessagePreviews(t *testing.T) {
+	tests := []struct {
+		name     string
+		previews bool
+		wantBody string
+	}{
+		{"previews on", true, "Is it still for sale?"},
+		{"previews off", false, pushFallbackTitle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := newPushGateway(t)
+			userID := uuid.New()
+			svc, _ := newTestPushService(t, gateway, userID, "phone")
+			h := &NotificationHandler{push: svc}
+
+			message := &domain.ChatMessage{ID: uuid.New(), ChatID: uuid.New(), Text: "Is it still for sale?"}
+			settings := domain.NotificationSettings{PushPreviews: tt.previews}
+			h.pushMessage(context.Background(), zap.NewNop(), userID, message, settings, "Aziz")
+
+			got := gateway.requests()
+			if len(got) != 1 {
+				t.Fatalf("gateway got %d requests, want 1", len(got))
+			}
+			if got[0].Notification.Title != "Aziz" {
+				t.Errorf("title = %q, want Aziz", got[0].Notification.Title)
+			}
+			if got[0].Notification.Body != tt.wantBody {
+				t.Errorf("body = %q, want %q", got[0].Notification.Body, tt.wantBody)
+			}
+			if got[0].Data["message_id"] != message.ID.String() {
+				t.Errorf("data = %v, want message_id %s", got[0].Data, message.ID)
+			}
+		})
+	}
+}
@@ -2,24 +2,47 @@ package service
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/events"
 	"github.com/barzurustami/bozor/internal/logger"
 	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/barzurustami/bozor/internal/validate"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 type RequestService struct {
+	tx       repository.Transactor
 	requests repository.RequestRepository
+	events   *events.Publisher
 }
 
-func NewRequestService(requests repository.RequestRepository) *RequestService {
-	return &RequestService{requests: requests}
+func NewRequestService(tx repository.Transactor, requests repository.RequestRepository, events *events.Publisher) *RequestService {
+	return &RequestService{tx: tx, requests: requests, events: events}
 }
 
+const (
+	maxRequestTitleLen       = 200
+	maxRequestDescriptionLen = 5000
+	maxRequestAddressLen     = 300
+)
+
 func (s *RequestService) Create(ctx context.Context, customerID uuid.UUID, title, description, address string) (*domain.JobRequest, error) {
+	title = strings.TrimSpace(title)
+	description = strings.TrimSpace(description)
+	address = strings.TrimSpace(address)
+
+	v := validate.New()
+	v.Length("title", title, 1, maxRequestTitleLen)
+	v.Length("description", description, 1, maxRequestDescriptionLen)
+	v.Length("address", address, 0, maxRequestAddressLen)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
 	request := &domain.JobRequest{
 		ID:          uuid.New(),
 		CustomerID:  customerID,
@@ -29,7 +52,17 @@ func (s *RequestService) Create(ctx context.Context, customerID uuid.UUID, title
 		CreatedAt:   time.Now().UTC(),
 	}
 
-	if err := s.requests.Create(ctx, request); err != nil {
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.requests.Create(ctx, request); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, events.TypeRequestCreated, events.RequestCreated{
+			RequestID:  request.ID,
+			CustomerID: request.CustomerID,
+			Title:      request.Title,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
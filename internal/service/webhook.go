@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/barzurustami/bozor/internal/validate"
+	"github.com/barzurustami/bozor/internal/webhook"
+	"github.com/google/uuid"
+)
+
+var ErrAdminOnly = apperr.New(apperr.CodeForbidden, "admin access required")
+
+const (
+	maxWebhookURLLength   = 2000
+	maxWebhookDeliveryLog = 100
+)
+
+// WebhookService lets admins manage partner webhooks and inspect their
+// delivery log.
+type WebhookService struct {
+	webhooks   repository.WebhookRepository
+	deliveries repository.WebhookDeliveryRepository
+	admins     []uuid.UUID
+}
+
+// WebhookInput describes a webhook to create, or the changes to apply to
+// one: nil fields keep their value.
+type WebhookInput struct {
+	URL        *string
+	EventTypes []string
+	Active     *bool
+}
+
+func NewWebhookService(webhooks repository.WebhookRepository, deliveries repository.WebhookDeliveryRepository, admins []uuid.UUID) *WebhookService {
+	return &WebhookService{webhooks: webhooks, deliveries: deliveries, admins: admins}
+}
+
+func (s *WebhookService) List(ctx context.Context, actorID uuid.UUID) ([]domain.Webhook, error) {
+	if err := s.ensureAdmin(actorID); err != nil {
+		return nil, err
+	}
+	return s.webhooks.List(ctx)
+}
+
+// Create registers a webhook with a fresh signing secret, which is only
+// returned here and by RotateSecret.
+func (s *WebhookService) Create(ctx context.Context, actorID uuid.UUID, input WebhookInput) (*domain.Webhook, error) {
+	if err := s.ensureAdmin(actorID); err != nil {
+		return nil, err
+	}
+
+	v := validate.New()
+	v.Check(input.URL != nil, "url", "is required")
+	v.Check(input.EventTypes != nil, "eventTypes", "is required")
+	validateWebhook(v, input)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	hook := &domain.Webhook{
+		ID:         uuid.New(),
+		URL:        *input.URL,
+		Secret:     secret,
+		EventTypes: input.EventTypes,
+		Active:     input.Active == nil || *input.Active,
+		CreatedBy:  actorID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.webhooks.Create(ctx, hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+func (s *WebhookService) Update(ctx context.Context, actorID, id uuid.UUID, input WebhookInput) (*domain.Webhook, error) {
+	if err := s.ensureAdmin(actorID); err != nil {
+		return nil, err
+	}
+
+	v := validate.New()
+	validateWebhook(v, input)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	hook, err := s.webhooks.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if input.URL != nil {
+		hook.URL = *input.URL
+	}
+	if input.EventTypes != nil {
+		hook.EventTypes = input.EventTypes
+	}
+	if input.Active != nil {
+		hook.Active = *input.Active
+	}
+	hook.UpdatedAt = time.Now().UTC()
+
+	if err := s.webhooks.Update(ctx, hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// RotateSecret replaces the signing secret. Deliveries sent after the call
+// are signed with the new secret, including retries of older events.
+func (s *WebhookService) RotateSecret(ctx context.Context, actorID, id uuid.UUID) (*domain.Webhook, error) {
+	if err := s.ensureAdmin(actorID); err != nil {
+		return nil, err
+	}
+
+	hook, err := s.webhooks.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	hook.Secret, err = webhook.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+	hook.UpdatedAt = time.Now().UTC()
+
+	if err := s.webhooks.Update(ctx, hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, actorID, id uuid.UUID) error {
+	if err := s.ensureAdmin(actorID); err != nil {
+		return err
+	}
+
+	deleted, err := s.webhooks.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Deliveries returns up to limit of the webhook's latest deliveries, newest
+// first.
+func (s *WebhookService) Deliveries(ctx context.Context, actorID, id uuid.UUID, limit int32) ([]domain.WebhookDelivery, error) {
+	if err := s.ensureAdmin(actorID); err != nil {
+		return nil, err
+	}
+	if _, err := s.webhooks.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > maxWebhookDeliveryLog {
+		limit = maxWebhookDeliveryLog
+	}
+	return s.deliveries.ListByWebhook(ctx, id, limit)
+}
+
+func (s *WebhookService) ensureAdmin(userID uuid.UUID) error {
+	if !slices.Contains(s.admins, userID) {
+		return ErrAdminOnly
+	}
+	return nil
+}
+
+func validateWebhook(v *validate.Validator, input WebhookInput) {
+	if input.URL != nil {
+		v.Length("url", *input.URL, 1, maxWebhookURLLength)
+		v.URL("url", *input.URL)
+	}
+	if input.EventTypes != nil {
+		v.Check(len(input.EventTypes) > 0, "eventTypes", "must list at least one event type")
+		for i, eventType := range input.EventTypes {
+			v.Check(slices.Contains(webhook.EventTypes, eventType), fmt.Sprintf("eventTypes[%d]", i), "is not a supported event type")
+		}
+	}
+}
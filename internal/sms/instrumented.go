@@ -0,0 +1,42 @@
+package sms
+
+import (
+	"context"
+
+	"github.com/barzurustami/bozor/internal/metrics"
+	"github.com/barzurustami/bozor/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentedSender traces sends and counts their outcomes per provider.
+type InstrumentedSender struct {
+	next     Sender
+	provider string
+}
+
+func NewInstrumentedSender(next Sender, provider string) *InstrumentedSender {
+	return &InstrumentedSender{next: next, provider: provider}
+}
+
+func (s *InstrumentedSender) Send(ctx context.Context, phone, message string) error {
+	ctx, span := tracing.Start(ctx, "sms.Send", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("sms.provider", s.provider)))
+	err := s.next.Send(ctx, phone, message)
+	tracing.End(span, err)
+
+	outcome := "sent"
+	if err != nil {
+		outcome = "failed"
+	}
+	metrics.SMSSends.WithLabelValues(s.provider, outcome).Inc()
+	return err
+}
+
+// Ping forwards to the wrapped sender when it supports it.
+func (s *InstrumentedSender) Ping(ctx context.Context) error {
+	if pinger, ok := s.next.(Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
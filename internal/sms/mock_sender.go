@@ -18,3 +18,8 @@ func (s *MockSender) Send(ctx context.Context, phone, message string) error {
 	log.Info("sms mock send", zap.String("phone", phone), zap.String("message", message))
 	return nil
 }
+
+// Ping always succeeds; the mock has no provider to reach.
+func (s *MockSender) Ping(ctx context.Context) error {
+	return nil
+}
@@ -5,3 +5,9 @@ import "context"
 type Sender interface {
 	Send(ctx context.Context, phone, message string) error
 }
+
+// Pinger is implemented by senders that can check the provider is reachable
+// without sending a message.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
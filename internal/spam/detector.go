@@ -0,0 +1,82 @@
+package spam
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// MinDuplicateLength is the shortest text checked for cross-chat duplicates;
+// short replies like "ok" or "hello" are repeated legitimately.
+const MinDuplicateLength = 20
+
+var (
+	linkPattern  = regexp.MustCompile(`(?i)(https?://|www\.|t\.me/|wa\.me/)\S+|\b[a-z0-9-]+\.(com|ru|tj|net|org|io|me|uz)\b`)
+	phonePattern = regexp.MustCompile(`\+?\d[\d\s().-]{7,}\d`)
+)
+
+// Reason names a heuristic that matched a message.
+type Reason string
+
+const (
+	ReasonLink      Reason = "link"
+	ReasonPhone     Reason = "phone"
+	ReasonDuplicate Reason = "duplicate"
+)
+
+// Verdict is the outcome of checking a message against a Policy.
+type Verdict struct {
+	Action  Action
+	Reasons []Reason
+}
+
+// Check applies the policy to text. duplicates is the number of other chats
+// that recently received the same text from the sender.
+func (p Policy) Check(text string, duplicates int) Verdict {
+	verdict := Verdict{Action: ActionAllow}
+
+	apply := func(reason Reason, action Action) {
+		if action == ActionAllow {
+			return
+		}
+		verdict.Reasons = append(verdict.Reasons, reason)
+		if action.severity() > verdict.Action.severity() {
+			verdict.Action = action
+		}
+	}
+
+	if linkPattern.MatchString(text) {
+		apply(ReasonLink, p.LinkAction)
+	}
+	if containsPhoneNumber(text) {
+		apply(ReasonPhone, p.PhoneAction)
+	}
+	if p.DuplicateChats > 0 && duplicates >= p.DuplicateChats {
+		apply(ReasonDuplicate, p.DuplicateAction)
+	}
+
+	return verdict
+}
+
+// DuplicateCandidate reports whether text is long enough to be compared across
+// chats.
+func DuplicateCandidate(text string) bool {
+	return utf8.RuneCountInString(strings.TrimSpace(text)) >= MinDuplicateLength
+}
+
+// containsPhoneNumber looks for runs of at least nine digits, which covers
+// local and international formats while ignoring prices and dates.
+func containsPhoneNumber(text string) bool {
+	for _, match := range phonePattern.FindAllString(text, -1) {
+		digits := 0
+		for _, r := range match {
+			if r >= '0' && r <= '9' {
+				digits++
+			}
+		}
+		if digits >= 9 {
+			return true
+		}
+	}
+	return false
+}
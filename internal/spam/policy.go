@@ -0,0 +1,60 @@
+package spam
+
+import (
+	"strings"
+	"time"
+)
+
+// Action is what happens to a message that trips a heuristic.
+type Action string
+
+const (
+	// ActionAllow delivers the message untouched.
+	ActionAllow Action = "allow"
+	// ActionFlag delivers the message and marks it for moderator review.
+	ActionFlag Action = "flag"
+	// ActionHold keeps the message visible to its sender only until a
+	// moderator reviews it.
+	ActionHold Action = "hold"
+)
+
+// ParseAction reads an action from configuration. Unknown values fall back
+// to ActionFlag so a typo never silently disables a check.
+func ParseAction(value string) Action {
+	switch Action(strings.ToLower(strings.TrimSpace(value))) {
+	case ActionAllow:
+		return ActionAllow
+	case ActionHold:
+		return ActionHold
+	default:
+		return ActionFlag
+	}
+}
+
+func (a Action) severity() int {
+	switch a {
+	case ActionHold:
+		return 2
+	case ActionFlag:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Policy configures chat rate limits and content heuristics.
+type Policy struct {
+	ChatCreateLimit  int
+	ChatCreateWindow time.Duration
+	MessageLimit     int
+	MessageWindow    time.Duration
+
+	// DuplicateChats is how many other chats may receive the same text from
+	// one sender within DuplicateWindow before DuplicateAction applies.
+	DuplicateChats  int
+	DuplicateWindow time.Duration
+
+	LinkAction      Action
+	PhoneAction     Action
+	DuplicateAction Action
+}
@@ -1,17 +1,25 @@
 package storage
 
 import (
+	"bytes"
 	"context"
-	"fmt"
 	"io"
+	"mime"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/99designs/gqlgen/graphql"
+	"github.com/barzurustami/bozor/internal/apperr"
+	"github.com/barzurustami/bozor/internal/tracing"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var ErrFileTooLarge = apperr.New(apperr.CodeValidation, "file too large")
+
 type LocalStorage struct {
 	dir     string
 	maxSize int64
@@ -21,15 +29,40 @@ func NewLocalStorage(dir string, maxSize int64) *LocalStorage {
 	return &LocalStorage{dir: dir, maxSize: maxSize}
 }
 
+// StoredFile describes an upload after it has been written to disk.
+type StoredFile struct {
+	Path        string
+	ContentType string
+	Size        int64
+}
+
 func (s *LocalStorage) Save(ctx context.Context, upload graphql.Upload) (string, error) {
-	_ = ctx
+	stored, err := s.SaveFile(ctx, upload)
+	if err != nil {
+		return "", err
+	}
+	return stored.Path, nil
+}
+
+// SaveFile stores the upload and reports its size and content type. The type is
+// sniffed from the file header and falls back to the declared type or the
+// file extension when sniffing is inconclusive.
+func (s *LocalStorage) SaveFile(ctx context.Context, upload graphql.Upload) (stored StoredFile, err error) {
+	_, span := tracing.Start(ctx, "storage.SaveFile", trace.WithAttributes(
+		attribute.String("storage.file.name", upload.Filename),
+		attribute.Int64("storage.file.declared_size", upload.Size),
+	))
+	defer func() {
+		span.SetAttributes(attribute.Int64("storage.file.size", stored.Size))
+		tracing.End(span, err)
+	}()
 
 	if upload.Size > 0 && upload.Size > s.maxSize {
-		return "", fmt.Errorf("file too large")
+		return StoredFile{}, ErrFileTooLarge
 	}
 
 	if err := os.MkdirAll(s.dir, 0o755); err != nil {
-		return "", err
+		return StoredFile{}, err
 	}
 
 	ext := strings.ToLower(filepath.Ext(upload.Filename))
@@ -40,22 +73,86 @@ func (s *LocalStorage) Save(ctx context.Context, upload graphql.Upload) (string,
 		defer closer.Close()
 	}
 
+	head := make([]byte, 512)
+	n, err := io.ReadFull(upload.File, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return StoredFile{}, err
+	}
+	head = head[:n]
+
 	out, err := os.Create(path)
 	if err != nil {
-		return "", err
+		return StoredFile{}, err
 	}
 	defer out.Close()
 
-	limited := &io.LimitedReader{R: upload.File, N: s.maxSize + 1}
+	body := io.MultiReader(bytes.NewReader(head), upload.File)
+	limited := &io.LimitedReader{R: body, N: s.maxSize + 1}
 	written, err := io.Copy(out, limited)
 	if err != nil {
 		_ = os.Remove(path)
-		return "", err
+		return StoredFile{}, err
 	}
 	if written > s.maxSize {
 		_ = os.Remove(path)
-		return "", fmt.Errorf("file too large")
+		return StoredFile{}, ErrFileTooLarge
+	}
+
+	return StoredFile{
+		Path:        filepath.ToSlash(filepath.Join("/uploads", name)),
+		ContentType: detectContentType(head, upload.ContentType, ext),
+		Size:        written,
+	}, nil
+}
+
+func (s *LocalStorage) Remove(ctx context.Context, path string) (err error) {
+	if path == "" {
+		return nil
 	}
 
-	return filepath.ToSlash(filepath.Join("/uploads", name)), nil
+	_, span := tracing.Start(ctx, "storage.Remove", trace.WithAttributes(attribute.String("storage.file.path", path)))
+	defer func() { tracing.End(span, err) }()
+
+	err = os.Remove(filepath.Join(s.dir, filepath.Base(path)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// CheckWritable creates and removes a probe file in the upload directory.
+func (s *LocalStorage) CheckWritable(ctx context.Context) error {
+	_ = ctx
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	probe, err := os.CreateTemp(s.dir, ".healthcheck-*")
+	if err != nil {
+		return err
+	}
+	name := probe.Name()
+	if err := probe.Close(); err != nil {
+		_ = os.Remove(name)
+		return err
+	}
+	return os.Remove(name)
+}
+
+func detectContentType(head []byte, declared, ext string) string {
+	contentType := http.DetectContentType(head)
+	if contentType == "application/octet-stream" || strings.HasPrefix(contentType, "text/plain") {
+		if declared != "" {
+			contentType = declared
+		} else if byExt := mime.TypeByExtension(ext); byExt != "" {
+			contentType = byExt
+		}
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return mediaType
 }
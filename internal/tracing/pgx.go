@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PgxTracer starts a span for every query and batch run through pgx.
+type PgxTracer struct{}
+
+var (
+	_ pgx.QueryTracer = PgxTracer{}
+	_ pgx.BatchTracer = PgxTracer{}
+)
+
+func (PgxTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = Start(ctx, "db.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "postgresql"),
+			attribute.String("db.operation.name", operationName(data.SQL)),
+			attribute.String("db.query.text", data.SQL),
+		),
+	)
+	return ctx
+}
+
+func (PgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("db.response.returned_rows", data.CommandTag.RowsAffected()))
+	End(span, data.Err)
+}
+
+func (PgxTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	ctx, _ = Start(ctx, "db.batch",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "postgresql"),
+			attribute.Int("db.operation.batch.size", data.Batch.Len()),
+		),
+	)
+	return ctx
+}
+
+func (PgxTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	if data.Err != nil {
+		trace.SpanFromContext(ctx).RecordError(data.Err)
+	}
+}
+
+func (PgxTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	End(trace.SpanFromContext(ctx), data.Err)
+}
+
+// operationName returns the leading SQL keyword, e.g. SELECT or INSERT.
+func operationName(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,75 @@
+// Package tracing configures OpenTelemetry and provides the spans shared by
+// the HTTP, GraphQL, database, storage and SMS layers.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/barzurustami/bozor"
+
+// RequestIDKey is the span attribute carrying the X-Request-ID header.
+const RequestIDKey = attribute.Key("request.id")
+
+type Config struct {
+	ServiceName string
+	Environment string
+	SampleRatio float64
+}
+
+// NewProvider builds a tracer provider that batches spans to exporter. Tests
+// can pass an in-memory exporter from go.opentelemetry.io/otel/sdk/trace/tracetest.
+func NewProvider(cfg Config, exporter sdktrace.SpanExporter) *sdktrace.TracerProvider {
+	res := resource.NewSchemaless(
+		attribute.String("service.name", cfg.ServiceName),
+		attribute.String("deployment.environment.name", cfg.Environment),
+	)
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+}
+
+// NewOTLPExporter exports over OTLP/HTTP. The endpoint and headers come from
+// the standard OTEL_EXPORTER_OTLP_* environment variables.
+func NewOTLPExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	return otlptracehttp.New(ctx)
+}
+
+// Install makes provider the global tracer provider and enables W3C trace
+// context propagation.
+func Install(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// End records err on the span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
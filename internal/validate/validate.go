@@ -0,0 +1,109 @@
+// Package validate collects field-level input errors and reports them as a
+// single VALIDATION error.
+package validate
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/barzurustami/bozor/internal/apperr"
+)
+
+const message = "invalid input"
+
+var phonePattern = regexp.MustCompile(`^\+?[1-9][0-9]{7,14}$`)
+
+// Validator records the first problem found for each field.
+type Validator struct {
+	fields map[string]string
+}
+
+func New() *Validator {
+	return &Validator{}
+}
+
+// Check records msg for field unless ok holds.
+func (v *Validator) Check(ok bool, field, msg string) {
+	if ok {
+		return
+	}
+	if v.fields == nil {
+		v.fields = make(map[string]string)
+	}
+	if _, exists := v.fields[field]; !exists {
+		v.fields[field] = msg
+	}
+}
+
+func (v *Validator) Required(field, value string) {
+	v.Check(strings.TrimSpace(value) != "", field, "is required")
+}
+
+// Length checks the number of characters in value. A zero min allows empty
+// values.
+func (v *Validator) Length(field, value string, min, max int) {
+	n := utf8.RuneCountInString(value)
+	if min > 0 && n < min {
+		if min == 1 {
+			v.Check(false, field, "is required")
+			return
+		}
+		v.Check(false, field, fmt.Sprintf("must be at least %d characters", min))
+		return
+	}
+	v.Check(n <= max, field, fmt.Sprintf("must be at most %d characters", max))
+}
+
+func (v *Validator) MaxItems(field string, n, max int) {
+	v.Check(n <= max, field, fmt.Sprintf("must have at most %d items", max))
+}
+
+// Phone accepts international numbers of 8 to 15 digits with an optional
+// leading plus.
+func (v *Validator) Phone(field, value string) {
+	v.Check(phonePattern.MatchString(value), field, "must be a phone number in international format")
+}
+
+// URL accepts absolute http and https URLs.
+func (v *Validator) URL(field, value string) {
+	parsed, err := url.Parse(value)
+	ok := err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+	v.Check(ok, field, "must be an http or https URL")
+}
+
+// ContentType checks a MIME type against allowed, which may contain exact
+// types or "type/*" wildcards.
+func (v *Validator) ContentType(field, contentType string, allowed []string) {
+	v.Check(contentTypeAllowed(contentType, allowed), field, fmt.Sprintf("file type %s is not allowed", contentType))
+}
+
+func (v *Validator) Valid() bool {
+	return len(v.fields) == 0
+}
+
+// Err returns nil when every check passed, otherwise a VALIDATION error
+// listing the failed fields.
+func (v *Validator) Err() error {
+	if v.Valid() {
+		return nil
+	}
+	return &apperr.Error{Code: apperr.CodeValidation, Message: message, Fields: v.fields}
+}
+
+func contentTypeAllowed(contentType string, allowed []string) bool {
+	for _, pattern := range allowed {
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(contentType, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if contentType == pattern {
+			return true
+		}
+	}
+	return false
+}
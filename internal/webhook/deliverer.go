@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/logger"
+	"github.com/barzurustami/bozor/internal/repository"
+	"go.uber.org/zap"
+)
+
+const maxRetryDelay = 6 * time.Hour
+
+type DelivererConfig struct {
+	PollInterval time.Duration
+	BatchSize    int32
+	// Lease is how long a claimed delivery stays hidden from other
+	// deliverers while it is being sent; it must exceed Timeout.
+	Lease       time.Duration
+	Timeout     time.Duration
+	MaxAttempts int
+	// RetryBase is the delay before the first retry; it doubles per attempt.
+	RetryBase time.Duration
+}
+
+// Deliverer polls queued deliveries and posts them to their webhooks. A
+// delivery succeeds on any 2xx response and is otherwise retried with
+// backoff until MaxAttempts.
+type Deliverer struct {
+	webhooks   repository.WebhookRepository
+	deliveries repository.WebhookDeliveryRepository
+	client     *http.Client
+	cfg        DelivererConfig
+}
+
+func NewDeliverer(webhooks repository.WebhookRepository, deliveries repository.WebhookDeliveryRepository, cfg DelivererConfig) *Deliverer {
+	return &Deliverer{
+		webhooks:   webhooks,
+		deliveries: deliveries,
+		client:     &http.Client{Timeout: cfg.Timeout},
+		cfg:        cfg,
+	}
+}
+
+// Run delivers until ctx is cancelled.
+func (d *Deliverer) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			count, err := d.DeliverOnce(ctx)
+			if err != nil && ctx.Err() == nil {
+				logger.FromContext(ctx).Error("webhook delivery failed", zap.Error(err))
+			}
+			if err != nil || count < int(d.cfg.BatchSize) {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverOnce claims one batch of due deliveries and sends it, returning the
+// number claimed.
+func (d *Deliverer) DeliverOnce(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	claimed, err := d.deliveries.Claim(ctx, now, now.Add(d.cfg.Lease), d.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range claimed {
+		if err := d.deliver(ctx, delivery); err != nil {
+			return len(claimed), err
+		}
+	}
+	return len(claimed), nil
+}
+
+// deliver sends one delivery and records the outcome. Endpoint failures are
+// stored on the delivery; only bookkeeping errors are returned.
+func (d *Deliverer) deliver(ctx context.Context, delivery domain.WebhookDelivery) error {
+	log := logger.FromContext(ctx).With(
+		zap.String("delivery_id", delivery.ID.String()),
+		zap.String("webhook_id", delivery.WebhookID.String()),
+	)
+
+	webhook, err := d.webhooks.GetByID(ctx, delivery.WebhookID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if !webhook.Active {
+		return d.deliveries.MarkFailed(ctx, delivery.ID, now, nil, "webhook is disabled")
+	}
+
+	status, err := d.send(ctx, webhook, delivery)
+	now = time.Now().UTC()
+	if err == nil {
+		return d.deliveries.MarkSucceeded(ctx, delivery.ID, now, *status)
+	}
+
+	if delivery.Attempts >= d.cfg.MaxAttempts {
+		log.Warn("webhook delivery gave up", zap.Int("attempts", delivery.Attempts), zap.Error(err))
+		return d.deliveries.MarkFailed(ctx, delivery.ID, now, status, err.Error())
+	}
+	return d.deliveries.Reschedule(ctx, delivery.ID, now.Add(retryDelay(d.cfg.RetryBase, delivery.Attempts)), status, err.Error())
+}
+
+// send posts the delivery and returns the response status, if any.
+func (d *Deliverer) send(ctx context.Context, webhook *domain.Webhook, delivery domain.WebhookDelivery) (*int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Bozor-Webhooks/1.0")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, time.Now(), delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	status := resp.StatusCode
+	if status < 200 || status >= 300 {
+		return &status, fmt.Errorf("endpoint returned %d", status)
+	}
+	return &status, nil
+}
+
+// retryDelay doubles the base delay per attempt, capped at maxRetryDelay.
+func retryDelay(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
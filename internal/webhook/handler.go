@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/barzurustami/bozor/internal/domain"
+	"github.com/barzurustami/bozor/internal/events"
+	"github.com/barzurustami/bozor/internal/repository"
+	"github.com/google/uuid"
+)
+
+// EventTypes are the events webhooks can subscribe to. Chat messages are
+// private to their participants and are not offered.
+var EventTypes = []string{
+	events.TypeRequestCreated,
+	events.TypeRequestPhotosAdded,
+	events.TypeChatCreated,
+}
+
+// envelope is the body posted to webhook endpoints.
+type envelope struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Handler queues a delivery for every active webhook subscribed to the
+// event. Sending happens in the Deliverer so a slow endpoint cannot hold up
+// other event handlers.
+type Handler struct {
+	webhooks   repository.WebhookRepository
+	deliveries repository.WebhookDeliveryRepository
+}
+
+func NewHandler(webhooks repository.WebhookRepository, deliveries repository.WebhookDeliveryRepository) *Handler {
+	return &Handler{webhooks: webhooks, deliveries: deliveries}
+}
+
+func (h *Handler) Name() string {
+	return "webhooks"
+}
+
+func (h *Handler) Handle(ctx context.Context, event events.Event) error {
+	webhooks, err := h.webhooks.ListActiveByEventType(ctx, event.Type)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	payload, err := json.Marshal(envelope{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt,
+		Data:      event.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, webhook := range webhooks {
+		_, err := h.deliveries.Create(ctx, &domain.WebhookDelivery{
+			ID:            uuid.New(),
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        domain.WebhookDeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
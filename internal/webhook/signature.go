@@ -0,0 +1,41 @@
+// Package webhook queues domain events for partner endpoints and delivers
+// them as signed HTTP requests.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Bozor-Signature"
+	EventHeader     = "X-Bozor-Event"
+	DeliveryHeader  = "X-Bozor-Delivery"
+)
+
+// Sign returns the signature header value for body sent at the given time:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">". Receivers should
+// recompute it with their secret and reject stale timestamps.
+func Sign(secret string, at time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSecret returns a random signing secret.
+func NewSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
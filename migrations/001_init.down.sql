@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS photos;
+DROP TABLE IF EXISTS job_requests;
+DROP TABLE IF EXISTS profiles;
+DROP TABLE IF EXISTS users;
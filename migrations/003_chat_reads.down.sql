@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_chat_messages_read_at;
+ALTER TABLE chat_messages DROP COLUMN IF EXISTS read_at;
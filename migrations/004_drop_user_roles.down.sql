@@ -0,0 +1,2 @@
+-- The dropped role values cannot be recovered; the column comes back empty.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS roles TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS chat_message_hides;
+
+ALTER TABLE chat_messages
+    DROP COLUMN IF EXISTS edited_at,
+    DROP COLUMN IF EXISTS deleted_at;
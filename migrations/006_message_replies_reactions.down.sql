@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS message_reactions;
+
+ALTER TABLE chat_messages DROP COLUMN IF EXISTS reply_to_id;
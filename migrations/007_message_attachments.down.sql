@@ -0,0 +1,3 @@
+-- Legacy uploads are still referenced by chat_messages.photo_path, so only the
+-- attachment rows are lost.
+DROP TABLE IF EXISTS message_attachments;
@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS message_attachments (
+    id UUID PRIMARY KEY,
+    message_id UUID NOT NULL REFERENCES chat_messages(id) ON DELETE CASCADE,
+    path TEXT NOT NULL,
+    mime_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    original_name TEXT NOT NULL,
+    duration_ms INTEGER,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_attachments_message_id
+    ON message_attachments(message_id);
+
+-- Legacy messages kept every upload in photo_path; expose them as attachments
+-- too, guessing the type from the file extension.
+INSERT INTO message_attachments (id, message_id, path, mime_type, size_bytes, original_name, position, created_at)
+SELECT
+    gen_random_uuid(),
+    m.id,
+    m.photo_path,
+    CASE lower(substring(m.photo_path FROM '\.([^./]+)$'))
+        WHEN 'jpg' THEN 'image/jpeg'
+        WHEN 'jpeg' THEN 'image/jpeg'
+        WHEN 'png' THEN 'image/png'
+        WHEN 'gif' THEN 'image/gif'
+        WHEN 'webp' THEN 'image/webp'
+        WHEN 'heic' THEN 'image/heic'
+        WHEN 'pdf' THEN 'application/pdf'
+        WHEN 'mp3' THEN 'audio/mpeg'
+        WHEN 'm4a' THEN 'audio/mp4'
+        WHEN 'ogg' THEN 'audio/ogg'
+        WHEN 'opus' THEN 'audio/ogg'
+        ELSE 'application/octet-stream'
+    END,
+    0,
+    substring(m.photo_path FROM '[^/]+$'),
+    0,
+    m.created_at
+FROM chat_messages m
+WHERE COALESCE(m.photo_path, '') <> ''
+    AND NOT EXISTS (
+        SELECT 1 FROM message_attachments a WHERE a.message_id = m.id
+    );
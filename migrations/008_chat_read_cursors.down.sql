@@ -0,0 +1,16 @@
+ALTER TABLE chat_messages
+    ADD COLUMN IF NOT EXISTS read_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_read_at ON chat_messages(read_at);
+
+-- Everything up to a reader's cursor counts as read at the cursor's time.
+UPDATE chat_messages m
+SET read_at = rc.read_at
+FROM chat_read_cursors rc
+JOIN chat_messages cursor_msg ON cursor_msg.id = rc.last_read_message_id
+WHERE m.chat_id = rc.chat_id
+    AND m.sender_id <> rc.user_id
+    AND (m.created_at, m.id) <= (cursor_msg.created_at, cursor_msg.id);
+
+DROP INDEX IF EXISTS idx_chat_messages_chat_created;
+DROP TABLE IF EXISTS chat_read_cursors;
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS chat_read_cursors (
+    chat_id UUID NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_read_message_id UUID NOT NULL REFERENCES chat_messages(id) ON DELETE CASCADE,
+    read_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (chat_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_chat_created
+    ON chat_messages(chat_id, created_at, id);
+
+-- Seed each reader's cursor from the newest message they had read under the
+-- per-message read_at model, then drop the column.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'chat_messages' AND column_name = 'read_at'
+    ) THEN
+        INSERT INTO chat_read_cursors (chat_id, user_id, last_read_message_id, read_at)
+        SELECT DISTINCT ON (m.chat_id, reader_id)
+            m.chat_id,
+            CASE WHEN m.sender_id = c.creator_id THEN c.initiator_id ELSE c.creator_id END AS reader_id,
+            m.id,
+            m.read_at
+        FROM chat_messages m
+        JOIN chats c ON c.id = m.chat_id
+        WHERE m.read_at IS NOT NULL
+        ORDER BY m.chat_id, reader_id, m.created_at DESC, m.id DESC
+        ON CONFLICT (chat_id, user_id) DO NOTHING;
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_chat_messages_read_at;
+ALTER TABLE chat_messages DROP COLUMN IF EXISTS read_at;
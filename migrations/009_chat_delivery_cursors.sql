@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS chat_delivery_cursors (
+    chat_id UUID NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_delivered_message_id UUID NOT NULL REFERENCES chat_messages(id) ON DELETE CASCADE,
+    delivered_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (chat_id, user_id)
+);
+
+-- Anything already read has necessarily been delivered.
+INSERT INTO chat_delivery_cursors (chat_id, user_id, last_delivered_message_id, delivered_at)
+SELECT chat_id, user_id, last_read_message_id, read_at
+FROM chat_read_cursors
+ON CONFLICT (chat_id, user_id) DO NOTHING;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS chat_participants (
+    chat_id UUID NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'member')),
+    joined_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (chat_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_participants_user_id
+    ON chat_participants(user_id);
+
+-- Existing two-party chats: the request owner owns the chat and the user who
+-- started it becomes a member.
+INSERT INTO chat_participants (chat_id, user_id, role, joined_at)
+SELECT id, creator_id, 'owner', created_at
+FROM chats
+ON CONFLICT (chat_id, user_id) DO NOTHING;
+
+INSERT INTO chat_participants (chat_id, user_id, role, joined_at)
+SELECT id, initiator_id, 'member', created_at
+FROM chats
+ON CONFLICT (chat_id, user_id) DO NOTHING;
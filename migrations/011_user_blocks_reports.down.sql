@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS moderation_reports;
+DROP TABLE IF EXISTS user_blocks;
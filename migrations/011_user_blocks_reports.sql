@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS user_blocks (
+    blocker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked_id
+    ON user_blocks(blocked_id);
+
+-- Reports keep a copy of the offending content so moderators can review it
+-- even after the chat or message is gone.
+CREATE TABLE IF NOT EXISTS moderation_reports (
+    id UUID PRIMARY KEY,
+    reporter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    chat_id UUID REFERENCES chats(id) ON DELETE SET NULL,
+    message_id UUID REFERENCES chat_messages(id) ON DELETE SET NULL,
+    reported_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL,
+    snapshot JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'reviewed', 'dismissed')),
+    created_at TIMESTAMPTZ NOT NULL,
+    reviewed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_reports_status
+    ON moderation_reports(status, created_at);
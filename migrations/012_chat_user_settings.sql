@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS chat_user_settings (
+    chat_id UUID NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    archived_at TIMESTAMPTZ,
+    muted_until TIMESTAMPTZ,
+    pinned_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (chat_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_user_settings_user_id
+    ON chat_user_settings(user_id);
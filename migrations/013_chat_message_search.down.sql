@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_chat_messages_text_search;
+ALTER TABLE chat_messages DROP COLUMN IF EXISTS text_search;
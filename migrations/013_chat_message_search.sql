@@ -0,0 +1,8 @@
+-- The simple configuration skips stemming and stop words, which keeps search
+-- predictable across the languages our users mix in chats.
+ALTER TABLE chat_messages
+    ADD COLUMN IF NOT EXISTS text_search TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector('simple', COALESCE(text, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_text_search
+    ON chat_messages USING GIN (text_search);
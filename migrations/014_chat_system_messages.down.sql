@@ -0,0 +1,6 @@
+-- System messages have no meaning without their kind, so they are removed.
+DELETE FROM chat_messages WHERE kind = 'system';
+
+ALTER TABLE chat_messages
+    DROP COLUMN IF EXISTS kind,
+    DROP COLUMN IF EXISTS payload;
@@ -0,0 +1,5 @@
+-- System messages record marketplace events in the chat timeline. sender_id
+-- holds the user whose action produced the event.
+ALTER TABLE chat_messages
+    ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'user' CHECK (kind IN ('user', 'system')),
+    ADD COLUMN IF NOT EXISTS payload JSONB;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_chat_messages_moderation_status;
+DROP INDEX IF EXISTS idx_chat_messages_sender_created_at;
+
+ALTER TABLE chat_messages
+    DROP COLUMN IF EXISTS moderation_status,
+    DROP COLUMN IF EXISTS moderation_reasons;
@@ -0,0 +1,13 @@
+ALTER TABLE chat_messages
+    ADD COLUMN IF NOT EXISTS moderation_status TEXT NOT NULL DEFAULT 'clean'
+        CHECK (moderation_status IN ('clean', 'flagged', 'held')),
+    ADD COLUMN IF NOT EXISTS moderation_reasons TEXT[];
+
+-- Supports duplicate-content lookups across a sender's recent messages.
+CREATE INDEX IF NOT EXISTS idx_chat_messages_sender_created_at
+    ON chat_messages(sender_id, created_at);
+
+-- Moderation queue of flagged and held messages.
+CREATE INDEX IF NOT EXISTS idx_chat_messages_moderation_status
+    ON chat_messages(moderation_status, created_at)
+    WHERE moderation_status <> 'clean';
@@ -0,0 +1,26 @@
+-- Domain events are written here in the same transaction as the change that
+-- produced them and delivered to in-process handlers by the dispatcher.
+CREATE TABLE IF NOT EXISTS outbox (
+    id UUID PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error TEXT,
+    dispatched_at TIMESTAMPTZ,
+    failed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending
+    ON outbox(next_attempt_at)
+    WHERE dispatched_at IS NULL AND failed_at IS NULL;
+
+-- Handlers that already succeeded for an event are skipped when it is
+-- retried for another handler.
+CREATE TABLE IF NOT EXISTS processed_events (
+    event_id UUID NOT NULL REFERENCES outbox(id) ON DELETE CASCADE,
+    handler TEXT NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (event_id, handler)
+);
@@ -0,0 +1,7 @@
+-- Automatic persisted queries: documents registered by clients under their
+-- SHA-256 hash, shared by every API instance.
+CREATE TABLE IF NOT EXISTS persisted_queries (
+    hash TEXT PRIMARY KEY,
+    query TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type TEXT NOT NULL CHECK (type IN ('new_message', 'new_offer', 'offer_accepted', 'review_received')),
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    request_id UUID REFERENCES job_requests(id) ON DELETE CASCADE,
+    chat_id UUID REFERENCES chats(id) ON DELETE CASCADE,
+    message_id UUID REFERENCES chat_messages(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    -- Events are delivered at least once; this keeps retries from
+    -- notifying twice.
+    UNIQUE (event_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created
+    ON notifications(user_id, created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_unread
+    ON notifications(user_id)
+    WHERE read_at IS NULL;
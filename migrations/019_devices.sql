@@ -0,0 +1,20 @@
+-- A token identifies one app installation. When it is registered again by
+-- another user (the device changed hands) the row moves to that user.
+CREATE TABLE IF NOT EXISTS devices (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform TEXT NOT NULL CHECK (platform IN ('ios', 'android', 'web')),
+    token TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_devices_user_id
+    ON devices(user_id);
+
+CREATE TABLE IF NOT EXISTS notification_settings (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    push_enabled BOOLEAN NOT NULL,
+    push_previews BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
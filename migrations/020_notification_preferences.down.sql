@@ -0,0 +1,33 @@
+DROP INDEX IF EXISTS idx_notifications_user_created;
+DROP INDEX IF EXISTS idx_notifications_unread;
+
+DELETE FROM notifications WHERE NOT in_app;
+ALTER TABLE notifications DROP COLUMN IF EXISTS in_app;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created
+    ON notifications(user_id, created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_unread
+    ON notifications(user_id)
+    WHERE read_at IS NULL;
+
+ALTER TABLE notification_settings
+    ADD COLUMN IF NOT EXISTS push_enabled BOOLEAN NOT NULL DEFAULT true;
+
+UPDATE notification_settings s
+SET push_enabled = false
+WHERE NOT EXISTS (
+    SELECT 1 FROM notification_preferences p
+    WHERE p.user_id = s.user_id AND p.channel = 'push' AND p.enabled
+) AND EXISTS (
+    SELECT 1 FROM notification_preferences p
+    WHERE p.user_id = s.user_id AND p.channel = 'push'
+);
+
+DROP TABLE IF EXISTS notification_preferences;
+
+ALTER TABLE notification_settings
+    DROP COLUMN IF EXISTS quiet_hours_enabled,
+    DROP COLUMN IF EXISTS quiet_hours_start,
+    DROP COLUMN IF EXISTS quiet_hours_end,
+    DROP COLUMN IF EXISTS time_zone;
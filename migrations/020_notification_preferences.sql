@@ -0,0 +1,39 @@
+ALTER TABLE notification_settings
+    ADD COLUMN IF NOT EXISTS quiet_hours_enabled BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS quiet_hours_start SMALLINT NOT NULL DEFAULT 1320 CHECK (quiet_hours_start BETWEEN 0 AND 1439),
+    ADD COLUMN IF NOT EXISTS quiet_hours_end SMALLINT NOT NULL DEFAULT 420 CHECK (quiet_hours_end BETWEEN 0 AND 1439),
+    ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'UTC';
+
+-- Only explicit choices are stored; missing rows fall back to the channel
+-- default.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel TEXT NOT NULL CHECK (channel IN ('in_app', 'push', 'sms')),
+    type TEXT NOT NULL CHECK (type IN ('new_message', 'new_offer', 'offer_accepted', 'review_received')),
+    enabled BOOLEAN NOT NULL,
+    PRIMARY KEY (user_id, channel, type)
+);
+
+-- The single push switch becomes a push preference per type.
+INSERT INTO notification_preferences (user_id, channel, type, enabled)
+SELECT s.user_id, 'push', t.type, false
+FROM notification_settings s
+CROSS JOIN (VALUES ('new_message'), ('new_offer'), ('offer_accepted'), ('review_received')) AS t(type)
+WHERE NOT s.push_enabled
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS push_enabled;
+
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS in_app BOOLEAN NOT NULL DEFAULT true;
+
+DROP INDEX IF EXISTS idx_notifications_user_created;
+DROP INDEX IF EXISTS idx_notifications_unread;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created
+    ON notifications(user_id, created_at DESC, id DESC)
+    WHERE in_app;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_unread
+    ON notifications(user_id)
+    WHERE read_at IS NULL AND in_app;
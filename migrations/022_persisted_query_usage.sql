@@ -0,0 +1,12 @@
+-- Persisted queries can be registered anonymously, so unused documents are
+-- pruned by last use rather than kept forever.
+ALTER TABLE persisted_queries
+    ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMPTZ;
+
+UPDATE persisted_queries SET last_used_at = created_at WHERE last_used_at IS NULL;
+
+ALTER TABLE persisted_queries
+    ALTER COLUMN last_used_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_persisted_queries_last_used_at
+    ON persisted_queries(last_used_at);
@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL schema migrations so they ship inside the
+// binaries. Each NNN_name.sql file has a matching NNN_name.down.sql that
+// reverts it.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS